@@ -0,0 +1,210 @@
+package atlantic
+
+import (
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// FieldError is returned when an input field holds an invalid value.
+type FieldError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("atlantic: invalid %s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// parseAddr parses an IP address returned by the API.
+func parseAddr(field string, value string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(value))
+	if err != nil {
+		return netip.Addr{}, &FieldError{Field: field, Value: value, Reason: "not an IP address"}
+	}
+	return addr, nil
+}
+
+// parsePrefixBits parses a prefix length given as "24", "/24" or a netmask such as "255.255.255.0".
+func parsePrefixBits(field string, value string) (int, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "/")
+
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 128 {
+		return n, nil
+	}
+
+	mask, err := netip.ParseAddr(value)
+	if err != nil || !mask.Is4() {
+		return 0, &FieldError{Field: field, Value: value, Reason: "not a prefix length or netmask"}
+	}
+
+	b := mask.As4()
+	m := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	ones := bits.LeadingZeros32(^m)
+	if m<<uint(ones) != 0 {
+		return 0, &FieldError{Field: field, Value: value, Reason: "netmask is not contiguous"}
+	}
+
+	return ones, nil
+}
+
+// parsePrefix parses a prefix in CIDR notation, or an address with the given number of bits.
+func parsePrefix(field string, value string, defaultBits int) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, &FieldError{Field: field, Value: value, Reason: "not a CIDR prefix"}
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := parseAddr(field, value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	prefix, err := addr.Prefix(defaultBits)
+	if err != nil {
+		return netip.Prefix{}, &FieldError{Field: field, Value: value, Reason: err.Error()}
+	}
+
+	return prefix, nil
+}
+
+// Addr returns the parsed address of the public IP.
+func (ip PublicIP) Addr() (netip.Addr, error) {
+	return parseAddr("ip_address", ip.Address)
+}
+
+// GatewayAddr returns the parsed gateway of the public IP.
+func (ip PublicIP) GatewayAddr() (netip.Addr, error) {
+	return parseAddr("ip_gateway", ip.Gateway)
+}
+
+// Prefix returns the subnet the public IP belongs to.
+func (ip PublicIP) Prefix() (netip.Prefix, error) {
+	addr, err := ip.Addr()
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	n, err := parsePrefixBits("ip_subnet", ip.Subnet)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	prefix, err := addr.Prefix(n)
+	if err != nil {
+		return netip.Prefix{}, &FieldError{Field: "ip_subnet", Value: ip.Subnet, Reason: err.Error()}
+	}
+
+	return prefix, nil
+}
+
+// IPAddr returns the parsed primary IPv4 address of the instance.
+func (i DescribeInstance) IPAddr() (netip.Addr, error) {
+	return parseAddr("vm_ip_address", i.VMIPAddress)
+}
+
+// IPv6Addr returns the parsed IPv6 address of the instance.
+func (i DescribeInstance) IPv6Addr() (netip.Addr, error) {
+	return parseAddr("vm_ipv6_address", i.VMIPv6Address)
+}
+
+// IPv6Prefix returns the IPv6 prefix routed to the instance. A prefix given without a length is taken to be a /64.
+func (i DescribeInstance) IPv6Prefix() (netip.Prefix, error) {
+	return parsePrefix("vm_ipv6_prefix", i.VMIPv6Prefix, 64)
+}
+
+// NetworkPrefix returns the prefix formed by the network address and prefix length of the private network.
+func (pn PrivateNetwork) NetworkPrefix() (netip.Prefix, error) {
+	if strings.Contains(pn.Network, "/") {
+		return parsePrefix("network", pn.Network, 0)
+	}
+
+	addr, err := parseAddr("network", pn.Network)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	n, err := parsePrefixBits("prefix", pn.Prefix)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	prefix, err := addr.Prefix(n)
+	if err != nil {
+		return netip.Prefix{}, &FieldError{Field: "prefix", Value: pn.Prefix, Reason: err.Error()}
+	}
+
+	return prefix, nil
+}
+
+// Range returns the first and last addresses of the private network's IP range, given either as
+// "first-last" or in CIDR notation.
+func (pn PrivateNetwork) Range() (netip.Addr, netip.Addr, error) {
+	if parts := strings.SplitN(pn.IPRange, "-", 2); len(parts) == 2 {
+		first, err := parseAddr("ip_range", parts[0])
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+
+		last, err := parseAddr("ip_range", parts[1])
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+
+		if last.Less(first) || first.BitLen() != last.BitLen() {
+			return netip.Addr{}, netip.Addr{}, &FieldError{Field: "ip_range", Value: pn.IPRange, Reason: "range is empty"}
+		}
+
+		return first, last, nil
+	}
+
+	prefix, err := parsePrefix("ip_range", pn.IPRange, 0)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+
+	return prefix.Addr(), lastAddr(prefix), nil
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << uint(7-i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// validateIPAddresses checks that each value is a valid IP address.
+func validateIPAddresses(field string, values []string) error {
+	for i, v := range values {
+		if _, err := netip.ParseAddr(v); err != nil {
+			return &FieldError{Field: fmt.Sprintf("%s[%d]", field, i), Value: v, Reason: "not an IP address"}
+		}
+	}
+	return nil
+}
+
+// Validate checks the IP addresses in the input.
+func (input *AssignPublicIPInput) Validate() error {
+	return validateIPAddresses("IPAddress", input.IPAddress)
+}
+
+// Validate checks the IP addresses in the input.
+func (input *UnassignPublicIPInput) Validate() error {
+	return validateIPAddresses("IPAddress", input.IPAddress)
+}
+
+// Validate checks the IP addresses in the input.
+func (input *ReleasePublicIPInput) Validate() error {
+	return validateIPAddresses("IPAddress", input.IPAddress)
+}
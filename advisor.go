@@ -0,0 +1,153 @@
+package atlantic
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// hoursPerYear is the number of hours committed to by each year of a reserved term.
+const hoursPerYear = 8760
+
+// TermRecommendation represents a recommendation to move an on-demand instance to a reserved term.
+type TermRecommendation struct {
+	InstanceID   string    `json:"instance_id"`
+	Name         string    `json:"name"`
+	PlanName     string    `json:"plan_name"`
+	AgeDays      float64   `json:"age_days"`
+	OnDemandRate float64   `json:"rate_per_hr"`
+	Term         string    `json:"term"`
+	TermRate     float64   `json:"term_rate_per_hr"`
+	BreakEven    time.Time `json:"break_even"`
+	Savings      float64   `json:"savings"`
+}
+
+// TermAdvice represents the reserved-term recommendations for the account.
+type TermAdvice struct {
+	Recommendations []TermRecommendation `json:"recommendations"`
+	TotalSavings    float64              `json:"total_savings"`
+	// Skipped lists the instances left out because their rate or creation date could not be parsed.
+	Skipped []SkippedInstance `json:"skipped,omitempty"`
+}
+
+// AdviseTerms recommends which on-demand instances to move to a 1-year or 3-year term. An instance
+// is expected to live at least as long again as it has already run, so a term is recommended when
+// its break-even point falls within the instance's current age. Of the qualifying terms, the one
+// with the largest projected savings is recommended. Instances whose cost cannot be computed are
+// listed in Skipped.
+func (client *Client) AdviseTerms(now time.Time) (*TermAdvice, error) {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := client.DescribePlan(&DescribePlanInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	plansByName := map[string]Plan{}
+	for _, p := range plans.Plans {
+		plansByName[strings.ToLower(p.Name)] = p
+	}
+
+	advice := &TermAdvice{Recommendations: []TermRecommendation{}}
+
+	for _, i := range instances {
+		plan, ok := plansByName[strings.ToLower(i.VMPlanName)]
+		if !ok {
+			continue
+		}
+
+		cost, err := NewInstanceCost(i, now)
+		if err != nil {
+			advice.Skipped = append(advice.Skipped, SkippedInstance{InstanceID: i.ID, Name: i.VMName, Reason: err.Error()})
+			continue
+		}
+
+		recommendation, ok := recommendTerm(cost, plan, now)
+		if !ok {
+			continue
+		}
+
+		advice.Recommendations = append(advice.Recommendations, recommendation)
+		advice.TotalSavings += recommendation.Savings
+	}
+
+	sort.Slice(advice.Recommendations, func(a, b int) bool {
+		return advice.Recommendations[a].Savings > advice.Recommendations[b].Savings
+	})
+
+	return advice, nil
+}
+
+// recommendTerm returns the most profitable term for an instance, if any term pays off.
+func recommendTerm(cost InstanceCost, plan Plan, now time.Time) (TermRecommendation, bool) {
+	var best TermRecommendation
+	found := false
+
+	for _, t := range []struct {
+		term  string
+		years float64
+	}{{"1", 1}, {"3", 3}} {
+		rate, err := plan.Rate(t.term)
+		if err != nil || rate <= 0 || rate >= cost.RatePerHour {
+			// A rate at or above the instance's own means it is already on this term or a cheaper one.
+			continue
+		}
+
+		termHours := t.years * hoursPerYear
+		breakEvenHours := rate * termHours / cost.RatePerHour
+		if breakEvenHours > cost.Hours {
+			continue
+		}
+
+		savings := (cost.RatePerHour - rate) * termHours
+		if found && savings <= best.Savings {
+			continue
+		}
+
+		best = TermRecommendation{
+			InstanceID:   cost.InstanceID,
+			Name:         cost.Name,
+			PlanName:     cost.PlanName,
+			AgeDays:      cost.Hours / 24,
+			OnDemandRate: cost.RatePerHour,
+			Term:         t.term,
+			TermRate:     rate,
+			BreakEven:    now.Add(time.Duration(breakEvenHours * float64(time.Hour))),
+			Savings:      savings,
+		}
+		found = true
+	}
+
+	return best, found
+}
+
+// WriteTable writes the recommendations as an aligned text table.
+func (advice *TermAdvice) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "INSTANCE\tNAME\tPLAN\tAGE (DAYS)\tRATE/HR\tTERM\tTERM RATE/HR\tBREAK-EVEN\tSAVINGS")
+	for _, r := range advice.Recommendations {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.0f\t%.4f\t%sy\t%.4f\t%s\t%.2f\n",
+			r.InstanceID, r.Name, r.PlanName, r.AgeDays, r.OnDemandRate, r.Term, r.TermRate,
+			r.BreakEven.Format("2006-01-02"), r.Savings)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t\t\t\t\t\t\t%.2f\n", advice.TotalSavings)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, i := range advice.Skipped {
+		if _, err := fmt.Fprintf(w, "skipped %s (%s): %s\n", i.InstanceID, i.Name, i.Reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
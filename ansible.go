@@ -0,0 +1,130 @@
+package atlantic
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// AnsibleInventory represents an Ansible dynamic inventory built from the account's instances.
+type AnsibleInventory struct {
+	Groups   map[string][]string
+	HostVars map[string]map[string]interface{}
+}
+
+// AnsibleInventory returns an Ansible dynamic inventory of every active instance.
+func (client *Client) AnsibleInventory() (*AnsibleInventory, error) {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAnsibleInventory(instances)
+}
+
+// NewAnsibleInventory returns an Ansible dynamic inventory of the given instances. Hosts are
+// named after vm_name and grouped by location, plan, image and status.
+func NewAnsibleInventory(instances []DescribeInstance) (*AnsibleInventory, error) {
+	inventory := &AnsibleInventory{
+		Groups:   map[string][]string{},
+		HostVars: map[string]map[string]interface{}{},
+	}
+
+	for _, i := range instances {
+		host := i.VMName
+		if _, exists := inventory.HostVars[host]; exists || host == "" {
+			host = strings.TrimPrefix(host+"-"+i.ID, "-")
+		}
+
+		vars, err := ansibleHostVars(i)
+		if err != nil {
+			return nil, err
+		}
+		inventory.HostVars[host] = vars
+
+		for _, group := range []string{
+			ansibleGroup("location", i.VMLocation),
+			ansibleGroup("plan", i.VMPlanName),
+			ansibleGroup("image", i.VMImage),
+			ansibleGroup("status", i.VMStatus),
+		} {
+			inventory.Groups[group] = append(inventory.Groups[group], host)
+		}
+	}
+
+	return inventory, nil
+}
+
+// ansibleHostVars returns the host variables for an instance: ansible_host, atlantic_ipv6 and
+// every described field prefixed with atlantic_.
+func ansibleHostVars(i DescribeInstance) (map[string]interface{}, error) {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{}
+	for k, v := range fields {
+		vars["atlantic_"+strings.ToLower(k)] = v
+	}
+
+	vars["ansible_host"] = i.VMIPAddress
+	vars["atlantic_ipv6"] = i.VMIPv6Address
+
+	return vars, nil
+}
+
+// ansibleGroup returns a group name that is a valid Ansible identifier.
+func ansibleGroup(kind string, value string) string {
+	if value == "" {
+		value = "unknown"
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(value))
+
+	return kind + "_" + name
+}
+
+// Host returns the host variables of a single host, or an empty set if the host is unknown.
+func (inventory *AnsibleInventory) Host(name string) map[string]interface{} {
+	if vars, ok := inventory.HostVars[name]; ok {
+		return vars
+	}
+	return map[string]interface{}{}
+}
+
+// MarshalJSON renders the inventory in the format expected from a dynamic inventory's --list output.
+func (inventory *AnsibleInventory) MarshalJSON() ([]byte, error) {
+	type group struct {
+		Hosts    []string `json:"hosts,omitempty"`
+		Children []string `json:"children,omitempty"`
+	}
+
+	doc := map[string]interface{}{}
+
+	var groups []string
+	for name, hosts := range inventory.Groups {
+		sorted := append([]string(nil), hosts...)
+		sort.Strings(sorted)
+		doc[name] = group{Hosts: sorted}
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	doc["all"] = group{Children: append(groups, "ungrouped")}
+	doc["_meta"] = map[string]interface{}{
+		"hostvars": inventory.HostVars,
+	}
+
+	return json.Marshal(doc)
+}
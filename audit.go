@@ -0,0 +1,39 @@
+package atlantic
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditRecord represents an entry written to a client's audit log.
+type AuditRecord struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target"`
+	Message string    `json:"message,omitempty"`
+	Forced  bool      `json:"forced,omitempty"`
+}
+
+// audit writes a record to the client's audit log, one JSON document per line.
+// It is a no-op when no audit log is configured.
+func (client *Client) audit(record AuditRecord) error {
+	if client.AuditLog == nil {
+		return nil
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	client.auditMu.Lock()
+	defer client.auditMu.Unlock()
+
+	_, err = client.AuditLog.Write(line)
+	return err
+}
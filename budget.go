@@ -0,0 +1,179 @@
+package atlantic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Budget represents the spending limits enforced before launching, resizing or reserving
+// resources. Limits are projected monthly costs, and a zero limit is not enforced.
+type Budget struct {
+	// Monthly caps the projected monthly cost of the whole fleet.
+	Monthly float64
+	// PerCall caps the projected monthly cost added by a single call.
+	PerCall float64
+	// PerLocation caps the projected monthly cost of the fleet in each location, keyed by location
+	// code. Codes are matched case-insensitively.
+	PerLocation map[string]float64
+	// PublicIPRatePerHour is the hourly price of an additional public IP. When it is zero,
+	// reservations are not checked and reserved IPs are not counted against the budget.
+	PublicIPRatePerHour float64
+}
+
+// BudgetExceededError is returned when a call would break the client's budget.
+type BudgetExceededError struct {
+	Limit     string
+	Cap       float64
+	Current   float64
+	Requested float64
+	Projected float64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("atlantic: %s budget of %.2f/month exceeded: current %.2f + requested %.2f = %.2f",
+		e.Limit, e.Cap, e.Current, e.Requested, e.Projected)
+}
+
+// checkBudget checks whether adding monthly cost in a location would break the client's budget.
+// The current cost counts every instance and, when priced, every reserved public IP.
+func (client *Client) checkBudget(location string, added float64) error {
+	budget := client.Budget
+
+	if budget.PerCall > 0 && added > budget.PerCall {
+		return &BudgetExceededError{
+			Limit:     "per-call",
+			Cap:       budget.PerCall,
+			Requested: added,
+			Projected: added,
+		}
+	}
+
+	var locationCap float64
+	for code, c := range budget.PerLocation {
+		if strings.EqualFold(code, location) {
+			locationCap = c
+		}
+	}
+	hasLocationCap := locationCap > 0
+
+	if budget.Monthly <= 0 && !hasLocationCap {
+		return nil
+	}
+
+	var fleet, inLocation float64
+	if hasLocationCap {
+		instances, err := client.describeInstances()
+		if err != nil {
+			return err
+		}
+
+		for _, i := range instances {
+			rate, _ := parseNumber(i.RatePerHour)
+			fleet += rate * HoursPerMonth
+			if strings.EqualFold(i.VMLocation, location) {
+				inLocation += rate * HoursPerMonth
+			}
+		}
+	} else {
+		instances, err := client.ListInstances()
+		if err != nil {
+			return err
+		}
+
+		for _, i := range instances.ListInstances {
+			rate, _ := parseNumber(i.RatePerHour)
+			fleet += rate * HoursPerMonth
+		}
+	}
+
+	if budget.PublicIPRatePerHour > 0 {
+		publicIPs, err := client.ListPublicIPs(&ListPublicIPsInput{})
+		if err != nil {
+			return err
+		}
+
+		ipMonthly := budget.PublicIPRatePerHour * HoursPerMonth
+		for _, ip := range publicIPs.PublicIPs {
+			fleet += ipMonthly
+			if strings.EqualFold(ip.Location, location) {
+				inLocation += ipMonthly
+			}
+		}
+	}
+
+	if budget.Monthly > 0 && fleet+added > budget.Monthly {
+		return &BudgetExceededError{
+			Limit:     "monthly",
+			Cap:       budget.Monthly,
+			Current:   fleet,
+			Requested: added,
+			Projected: fleet + added,
+		}
+	}
+
+	if hasLocationCap && inLocation+added > locationCap {
+		return &BudgetExceededError{
+			Limit:     fmt.Sprintf("location %s", location),
+			Cap:       locationCap,
+			Current:   inLocation,
+			Requested: added,
+			Projected: inLocation + added,
+		}
+	}
+
+	return nil
+}
+
+// checkRunInstanceBudget checks the instances requested by a RunInstance input against the budget.
+func (client *Client) checkRunInstanceBudget(input *RunInstanceInput) error {
+	if client.Budget == nil || input.OverrideBudget {
+		return nil
+	}
+
+	estimate, err := client.EstimateRunInstance(input)
+	if err != nil {
+		return err
+	}
+
+	return client.checkBudget(input.Location, estimate.Monthly)
+}
+
+// checkResizeInstanceBudget checks the rate increase of a ResizeInstance input against the budget.
+func (client *Client) checkResizeInstanceBudget(input *ResizeInstanceInput) error {
+	if client.Budget == nil || input.OverrideBudget {
+		return nil
+	}
+
+	described, err := client.describeInstance(&DescribeInstanceInput{InstanceID: input.InstanceID})
+	if err != nil {
+		return err
+	}
+	i := described.DescribeInstance
+
+	current, _ := parseNumber(i.RatePerHour)
+
+	rate, err := client.planRate(input.PlanName, "")
+	if err != nil {
+		return err
+	}
+
+	if rate <= current {
+		return nil
+	}
+
+	return client.checkBudget(i.VMLocation, (rate-current)*HoursPerMonth)
+}
+
+// checkReservePublicIPBudget checks the IPs requested by a ReservePublicIP input against the budget.
+func (client *Client) checkReservePublicIPBudget(input *ReservePublicIPInput) error {
+	if client.Budget == nil || input.OverrideBudget || client.Budget.PublicIPRatePerHour <= 0 {
+		return nil
+	}
+
+	qty := input.Qty
+	if qty < 1 {
+		qty = 1
+	}
+
+	return client.checkBudget(input.Location, client.Budget.PublicIPRatePerHour*float64(qty)*HoursPerMonth)
+}
@@ -0,0 +1,54 @@
+package atlantic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// requestFields are the form fields set on every request, which Call parameters may not override.
+var requestFields = map[string]bool{
+	"format":         true,
+	"version":        true,
+	"acsaccesskeyid": true,
+	"timestamp":      true,
+	"rndguid":        true,
+	"signature":      true,
+	"action":         true,
+}
+
+// Call sends any action with arbitrary parameters and decodes the response into out, which may
+// be a pointer to a struct, a map or a json.RawMessage, or nil to discard the response. It is
+// signed and its errors are handled exactly like the typed methods, which makes it usable for
+// actions and parameters this library does not wrap yet. Parameters are sent as form fields, so
+// values may contain any character.
+func (client *Client) Call(ctx context.Context, action string, params map[string]string, out interface{}) error {
+	if action == "" {
+		return fmt.Errorf("atlantic: Action must be provided")
+	}
+
+	if strings.ContainsAny(action, "&=") {
+		return fmt.Errorf("atlantic: invalid action %q", action)
+	}
+
+	form := url.Values{}
+	for k, v := range params {
+		if k == "" || requestFields[strings.ToLower(k)] {
+			return fmt.Errorf("atlantic: invalid parameter name %q", k)
+		}
+		form.Set(k, v)
+	}
+
+	response, err := client.requestParams(ctx, action, form)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(response), out)
+}
@@ -0,0 +1,149 @@
+package atlantic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// CatalogCache caches the responses of the catalog actions (describe-plan, describe-image,
+// list-locations and list-private-networks), whose data rarely changes. It is safe for
+// concurrent use, and concurrent misses for the same action share a single request.
+type CatalogCache struct {
+	// TTL is how long a response is served from the cache.
+	TTL time.Duration
+	// StaleTTL is how long past its TTL a response is still served when the API fails.
+	StaleTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]catalogEntry
+	calls   map[string]*catalogCall
+}
+
+// catalogEntry represents a cached response.
+type catalogEntry struct {
+	Response string    `json:"response"`
+	Fetched  time.Time `json:"fetched"`
+}
+
+// catalogCall represents an in-flight request shared by concurrent misses.
+type catalogCall struct {
+	done     chan struct{}
+	response string
+	err      error
+}
+
+// NewCatalogCache returns a catalog cache serving responses for ttl.
+func NewCatalogCache(ttl time.Duration) *CatalogCache {
+	return &CatalogCache{
+		TTL:     ttl,
+		entries: map[string]catalogEntry{},
+		calls:   map[string]*catalogCall{},
+	}
+}
+
+// Invalidate removes every cached response.
+func (cache *CatalogCache) Invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = map[string]catalogEntry{}
+}
+
+// Save writes a snapshot of the cached responses to filename.
+func (cache *CatalogCache) Save(filename string) error {
+	cache.mu.Lock()
+	data, err := json.Marshal(cache.entries)
+	cache.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, data, 0600)
+}
+
+// Load reads a snapshot written by Save, keeping the time each response was originally fetched.
+func (cache *CatalogCache) Load(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var entries map[string]catalogEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.entries == nil {
+		cache.entries = map[string]catalogEntry{}
+	}
+
+	for action, entry := range entries {
+		if current, ok := cache.entries[action]; !ok || entry.Fetched.After(current.Fetched) {
+			cache.entries[action] = entry
+		}
+	}
+
+	return nil
+}
+
+// get returns the cached response for action, calling fetch on a miss.
+func (cache *CatalogCache) get(action string, fetch func() (string, error)) (string, error) {
+	cache.mu.Lock()
+
+	if cache.entries == nil {
+		cache.entries = map[string]catalogEntry{}
+	}
+	if cache.calls == nil {
+		cache.calls = map[string]*catalogCall{}
+	}
+
+	entry, cached := cache.entries[action]
+	if cached && time.Since(entry.Fetched) < cache.TTL {
+		cache.mu.Unlock()
+		return entry.Response, nil
+	}
+
+	call, inFlight := cache.calls[action]
+	if !inFlight {
+		call = &catalogCall{done: make(chan struct{})}
+		cache.calls[action] = call
+	}
+	cache.mu.Unlock()
+
+	if inFlight {
+		<-call.done
+	} else {
+		call.response, call.err = fetch()
+
+		cache.mu.Lock()
+		if call.err == nil {
+			cache.entries[action] = catalogEntry{Response: call.response, Fetched: time.Now()}
+		}
+		delete(cache.calls, action)
+		cache.mu.Unlock()
+
+		close(call.done)
+	}
+
+	if call.err != nil && cached && time.Since(entry.Fetched) < cache.TTL+cache.StaleTTL {
+		return entry.Response, nil
+	}
+
+	return call.response, call.err
+}
+
+// catalogRequest sends a catalog request through the client's catalog cache, if any.
+func (client *Client) catalogRequest(action string) (string, error) {
+	if client.Catalog == nil {
+		return client.request(action)
+	}
+
+	return client.Catalog.get(action, func() (string, error) {
+		return client.request(action)
+	})
+}
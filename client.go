@@ -2,16 +2,16 @@ package atlantic
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
@@ -23,7 +23,23 @@ type Client struct {
 	EndPoint   string
 	Format     string
 	AccessKey  string
-	PrivateKey string
+	PrivateKey Secret
+
+	// Signer, when set, signs requests instead of an HMACSigner over PrivateKey.
+	Signer Signer
+
+	// TerminationPolicy, when set, guards TerminateInstance against removing protected instances.
+	TerminationPolicy *TerminationPolicy
+	// ResolveNames, when set, lets every input accept names wherever IDs are expected.
+	ResolveNames bool
+	// Catalog, when set, caches the responses of plan, image, location and private network requests.
+	Catalog *CatalogCache
+	// Budget, when set, is checked before launching, resizing or reserving resources.
+	Budget *Budget
+	// AuditLog, when set, receives a JSON line for every audited operation.
+	AuditLog io.Writer
+
+	auditMu sync.Mutex
 }
 
 // ClientError represents an Atlantic API client error.
@@ -49,26 +65,38 @@ func NewClient(accesskey string, privatekey string) *Client {
 		EndPoint:   "https://cloudapi.atlantic.net/",
 		Format:     "json",
 		AccessKey:  accesskey,
-		PrivateKey: privatekey,
+		PrivateKey: Secret(privatekey),
 	}
 }
 
-// generateSignature returns a signature required when sending a client request.
-func (client *Client) generateSignature(timeSinceEpoch int64, randomUUID string) string {
-	key := []byte(client.PrivateKey)
-	stringToSign := fmt.Sprintf("%d%s", timeSinceEpoch, randomUUID)
-
-	m := hmac.New(sha256.New, key)
-	m.Write([]byte(stringToSign))
-
-	return base64.StdEncoding.EncodeToString(m.Sum(nil))
+// NewClientWithSigner returns a new Atlantic API client that signs requests with signer and
+// holds no private key.
+func NewClientWithSigner(accesskey string, signer Signer) *Client {
+	client := NewClient(accesskey, "")
+	client.Signer = signer
+	return client
 }
 
 // request sends a request to Atlantic's API.
 func (client *Client) request(action string) (string, error) {
+	return client.requestContext(context.Background(), action)
+}
+
+// requestContext sends a request to Atlantic's API, aborting it when ctx is done.
+func (client *Client) requestContext(ctx context.Context, action string) (string, error) {
+	return client.requestParams(ctx, action, nil)
+}
+
+// requestParams sends a request to Atlantic's API with params as additional form fields, which
+// are escaped like every other field.
+func (client *Client) requestParams(ctx context.Context, action string, params url.Values) (string, error) {
 	randomUUID := uuid.NewV4().String()
 	timeSinceEpoch := time.Now().Unix()
-	signature := client.generateSignature(timeSinceEpoch, randomUUID)
+	signature, err := client.signer().Sign(ctx, timeSinceEpoch, randomUUID)
+	if err != nil {
+		return "", err
+	}
+
 	form := url.Values{}
 	form.Add("Format", client.Format)
 	form.Add("Version", client.Version)
@@ -78,6 +106,12 @@ func (client *Client) request(action string) (string, error) {
 	form.Add("Signature", signature)
 	form.Add("Action", action)
 
+	for k, vv := range params {
+		for _, v := range vv {
+			form.Add(k, v)
+		}
+	}
+
 	encodedForm := form.Encode()
 
 	request, err := http.NewRequest("POST", client.EndPoint, strings.NewReader(encodedForm))
@@ -85,6 +119,7 @@ func (client *Client) request(action string) (string, error) {
 		return "", err
 	}
 
+	request = request.WithContext(ctx)
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	response, err := http.DefaultClient.Do(request)
@@ -0,0 +1,175 @@
+package atlantic
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+const (
+	// DefaultCloneNameTemplate is the naming template used for clones when none is given.
+	DefaultCloneNameTemplate = "{{.Source}}-clone-{{.Index}}"
+
+	defaultPollInterval = 10 * time.Second
+	defaultWaitTimeout  = 30 * time.Minute
+)
+
+// CloneInstanceInput represents the input for cloning an instance.
+type CloneInstanceInput struct {
+	SourceInstanceID string
+	// PlanName defaults to the plan of the source instance.
+	PlanName string
+	// Location defaults to the location of the source instance.
+	Location string
+	Qty      int
+	// NameTemplate is a text/template rendered with .Source (the source name) and .Index
+	// (starting at 1) to name each clone. It defaults to DefaultCloneNameTemplate.
+	NameTemplate string
+	// Quiesce shuts a running source instance down while it is cloned and powers it back on
+	// afterwards. A source that is already stopped is left stopped.
+	Quiesce      bool
+	EnableIPv6   bool
+	EnableBackup bool
+	Term         string
+	KeyID        string
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// CloneInstanceOutput represents the output from cloning an instance.
+type CloneInstanceOutput struct {
+	RunInstances []RunInstance
+}
+
+// cloneName holds the values available to a clone naming template.
+type cloneName struct {
+	Source string
+	Index  int
+}
+
+// CloneInstance creates one or more copies of an existing instance and waits for them to run.
+// On failure, the clones created so far are returned along with the error.
+func (client *Client) CloneInstance(input *CloneInstanceInput) (output *CloneInstanceOutput, err error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
+	if input.SourceInstanceID == "" {
+		return nil, fmt.Errorf("atlantic: Source instance ID must be provided")
+	}
+
+	nameTemplate := input.NameTemplate
+	if nameTemplate == "" {
+		nameTemplate = DefaultCloneNameTemplate
+	}
+
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("atlantic: invalid name template: %v", err)
+	}
+
+	described, err := client.describeInstance(&DescribeInstanceInput{InstanceID: input.SourceInstanceID})
+	if err != nil {
+		return nil, err
+	}
+	source := described.DescribeInstance
+
+	planName := input.PlanName
+	if planName == "" {
+		planName = source.VMPlanName
+	}
+
+	location := input.Location
+	if location == "" {
+		location = source.VMLocation
+	}
+
+	qty := input.Qty
+	if qty < 1 {
+		qty = 1
+	}
+
+	if input.Quiesce && source.VMStatus == InstanceStatusRunning {
+		if _, err := client.ShutdownInstance(&ShutdownInstanceInput{InstanceID: []string{input.SourceInstanceID}}); err != nil {
+			return nil, err
+		}
+
+		defer func() {
+			_, powerOnErr := client.PowerOnInstance(&PowerOnInstanceInput{InstanceID: []string{input.SourceInstanceID}})
+			if powerOnErr != nil && err == nil {
+				err = powerOnErr
+			}
+		}()
+
+		if err := client.waitForStatus(input.SourceInstanceID, InstanceStatusStopped, input.PollInterval, input.Timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	output = &CloneInstanceOutput{}
+
+	for i := 1; i <= qty; i++ {
+		var name bytes.Buffer
+		if err := tmpl.Execute(&name, cloneName{Source: source.VMName, Index: i}); err != nil {
+			return output, fmt.Errorf("atlantic: invalid name template: %v", err)
+		}
+
+		run, err := client.RunInstance(&RunInstanceInput{
+			ServerName:   name.String(),
+			ImageID:      source.VMImage,
+			PlanName:     planName,
+			Location:     location,
+			EnableIPv6:   input.EnableIPv6,
+			EnableBackup: input.EnableBackup,
+			CloneImage:   input.SourceInstanceID,
+			Qty:          1,
+			Term:         input.Term,
+			KeyID:        input.KeyID,
+		})
+		if err != nil {
+			return output, err
+		}
+
+		output.RunInstances = append(output.RunInstances, run.RunInstances...)
+	}
+
+	for _, i := range output.RunInstances {
+		if err := client.waitForStatus(i.ID, InstanceStatusRunning, input.PollInterval, input.Timeout); err != nil {
+			return output, err
+		}
+	}
+
+	return output, nil
+}
+
+// waitForStatus polls an instance until it reaches the given status or the timeout expires.
+func (client *Client) waitForStatus(instanceID string, status string, interval time.Duration, timeout time.Duration) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		described, err := client.describeInstance(&DescribeInstanceInput{InstanceID: instanceID})
+		if err != nil {
+			return err
+		}
+
+		current := described.DescribeInstance.VMStatus
+		if current == status {
+			return nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("atlantic: timed out waiting for instance %s to reach %s (currently %s)", instanceID, status, current)
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,56 @@
+// Command atlantic-inventory is an Ansible dynamic inventory for Atlantic.Net cloud servers.
+//
+// Credentials are read from the ATLANTIC_ACCESS_KEY and ATLANTIC_PRIVATE_KEY environment variables.
+//
+// Usage:
+//
+//	atlantic-inventory --list
+//	atlantic-inventory --host <hostname>
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	atlantic "github.com/kbrebanov/go-atlantic"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list all hosts and groups")
+	host := flag.String("host", "", "show the variables of a single host")
+	flag.Parse()
+
+	if !*list && *host == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	accessKey := os.Getenv("ATLANTIC_ACCESS_KEY")
+	privateKey := os.Getenv("ATLANTIC_PRIVATE_KEY")
+	if accessKey == "" || privateKey == "" {
+		fmt.Fprintln(os.Stderr, "atlantic-inventory: ATLANTIC_ACCESS_KEY and ATLANTIC_PRIVATE_KEY must be set")
+		os.Exit(1)
+	}
+
+	client := atlantic.NewClient(accessKey, privateKey)
+
+	inventory, err := client.AnsibleInventory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "atlantic-inventory: %v\n", err)
+		os.Exit(1)
+	}
+
+	var output interface{} = inventory
+	if !*list {
+		output = inventory.Host(*host)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		fmt.Fprintf(os.Stderr, "atlantic-inventory: %v\n", err)
+		os.Exit(1)
+	}
+}
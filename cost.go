@@ -0,0 +1,246 @@
+package atlantic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HoursPerMonth is the average number of hours in a month used for monthly projections.
+const HoursPerMonth = 730
+
+// DefaultCostPrefixSeparator separates the name prefix used to group costs from the rest of an instance name.
+const DefaultCostPrefixSeparator = "-"
+
+// InstanceCost represents the accrued and projected cost of an instance.
+type InstanceCost struct {
+	InstanceID  string
+	Name        string
+	Location    string
+	PlanName    string
+	RatePerHour float64
+	Created     time.Time
+	Hours       float64
+	Accrued     float64
+	Monthly     float64
+}
+
+// CostTotals represents accrued and projected monthly costs.
+type CostTotals struct {
+	Accrued float64
+	Monthly float64
+}
+
+// CostBreakdown represents costs totalled overall and by location, plan and name prefix.
+type CostBreakdown struct {
+	Total      CostTotals
+	ByLocation map[string]CostTotals
+	ByPlan     map[string]CostTotals
+	ByPrefix   map[string]CostTotals
+}
+
+// CostReport represents the costs of the instances on the account.
+type CostReport struct {
+	Instances []InstanceCost
+	Breakdown *CostBreakdown
+	// Skipped lists the instances left out because their rate or creation date could not be parsed.
+	Skipped []SkippedInstance
+}
+
+// SkippedInstance represents an instance left out of a report, and why.
+type SkippedInstance struct {
+	InstanceID string `json:"instance_id"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason"`
+}
+
+// CostEstimate represents the projected cost of running new instances.
+type CostEstimate struct {
+	PlanName    string
+	Location    string
+	Term        string
+	Qty         int
+	RatePerHour float64
+	Hourly      float64
+	Monthly     float64
+}
+
+// NewInstanceCost returns the cost of an instance accrued between its creation and now,
+// along with its projected monthly cost.
+func NewInstanceCost(i DescribeInstance, now time.Time) (InstanceCost, error) {
+	rate, err := parseNumber(i.RatePerHour)
+	if err != nil {
+		return InstanceCost{}, fmt.Errorf("atlantic: invalid rate %q for instance %s", i.RatePerHour, i.ID)
+	}
+
+	created, err := parseTime(i.VMCreatedDate)
+	if err != nil {
+		return InstanceCost{}, fmt.Errorf("atlantic: invalid created date %q for instance %s", i.VMCreatedDate, i.ID)
+	}
+
+	hours := now.Sub(created).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+
+	return InstanceCost{
+		InstanceID:  i.ID,
+		Name:        i.VMName,
+		Location:    i.VMLocation,
+		PlanName:    i.VMPlanName,
+		RatePerHour: rate,
+		Created:     created,
+		Hours:       hours,
+		Accrued:     rate * hours,
+		Monthly:     rate * HoursPerMonth,
+	}, nil
+}
+
+// CostReport returns the accrued and projected monthly cost of every active instance, broken
+// down by location, plan and the name prefix before prefixSeparator. Instances whose cost cannot
+// be computed are listed in Skipped rather than failing the report.
+func (client *Client) CostReport(now time.Time, prefixSeparator string) (*CostReport, error) {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CostReport{}
+	for _, i := range instances {
+		cost, err := NewInstanceCost(i, now)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedInstance{InstanceID: i.ID, Name: i.VMName, Reason: err.Error()})
+			continue
+		}
+		report.Instances = append(report.Instances, cost)
+	}
+
+	report.Breakdown = NewCostBreakdown(report.Instances, prefixSeparator)
+
+	return report, nil
+}
+
+// NewCostBreakdown totals instance costs overall and by location, plan and the name prefix before
+// prefixSeparator. The separator defaults to DefaultCostPrefixSeparator.
+func NewCostBreakdown(costs []InstanceCost, prefixSeparator string) *CostBreakdown {
+	if prefixSeparator == "" {
+		prefixSeparator = DefaultCostPrefixSeparator
+	}
+
+	breakdown := &CostBreakdown{
+		ByLocation: map[string]CostTotals{},
+		ByPlan:     map[string]CostTotals{},
+		ByPrefix:   map[string]CostTotals{},
+	}
+
+	for _, c := range costs {
+		breakdown.Total = breakdown.Total.add(c)
+		breakdown.ByLocation[c.Location] = breakdown.ByLocation[c.Location].add(c)
+		breakdown.ByPlan[c.PlanName] = breakdown.ByPlan[c.PlanName].add(c)
+
+		prefix := strings.SplitN(c.Name, prefixSeparator, 2)[0]
+		breakdown.ByPrefix[prefix] = breakdown.ByPrefix[prefix].add(c)
+	}
+
+	return breakdown
+}
+
+func (totals CostTotals) add(c InstanceCost) CostTotals {
+	return CostTotals{
+		Accrued: totals.Accrued + c.Accrued,
+		Monthly: totals.Monthly + c.Monthly,
+	}
+}
+
+// EstimateRunInstance returns the projected cost of the instances described by a RunInstance input.
+func (client *Client) EstimateRunInstance(input *RunInstanceInput) (*CostEstimate, error) {
+	if input.PlanName == "" {
+		return nil, fmt.Errorf("atlantic: Plan name must be provided")
+	}
+
+	rate, err := client.planRate(input.PlanName, input.Term)
+	if err != nil {
+		return nil, err
+	}
+
+	qty := input.Qty
+	if qty < 1 {
+		qty = 1
+	}
+
+	return &CostEstimate{
+		PlanName:    input.PlanName,
+		Location:    input.Location,
+		Term:        input.Term,
+		Qty:         qty,
+		RatePerHour: rate,
+		Hourly:      rate * float64(qty),
+		Monthly:     rate * float64(qty) * HoursPerMonth,
+	}, nil
+}
+
+// planRate returns the hourly rate of a plan for the given term.
+func (client *Client) planRate(planName string, term string) (float64, error) {
+	plans, err := client.DescribePlan(&DescribePlanInput{PlanName: planName})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range plans.Plans {
+		if strings.EqualFold(p.Name, planName) {
+			return p.Rate(term)
+		}
+	}
+
+	return 0, fmt.Errorf("atlantic: plan %q not found", planName)
+}
+
+// Rate returns the hourly rate of the plan for a term of "1" or "3" years, or the on-demand
+// rate when term is empty.
+func (p Plan) Rate(term string) (float64, error) {
+	value := p.RatePerHour
+	switch normalizeTerm(term) {
+	case "":
+	case "1":
+		value = p.RatePerHour1Year
+	case "3":
+		value = p.RatePerHour3Year
+	default:
+		return 0, fmt.Errorf("atlantic: unknown term %q", term)
+	}
+
+	rate, err := parseNumber(value)
+	if err != nil {
+		return 0, fmt.Errorf("atlantic: invalid rate %q for plan %s", value, p.Name)
+	}
+
+	return rate, nil
+}
+
+// normalizeTerm reduces term spellings such as "1y" or "3-year" to the number of years.
+func normalizeTerm(term string) string {
+	term = strings.ToLower(strings.TrimSpace(term))
+	for _, suffix := range []string{"years", "year", "yr", "y"} {
+		term = strings.TrimSuffix(term, suffix)
+	}
+	return strings.Trim(term, "- ")
+}
+
+// parseTime parses a date returned by the API, given either as a Unix timestamp or as a
+// "2006-01-02 15:04:05" date in UTC.
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("atlantic: invalid time %q", value)
+}
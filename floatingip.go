@@ -0,0 +1,233 @@
+package atlantic
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultConfirmTimeout  = 2 * time.Minute
+	defaultRollbackTimeout = time.Minute
+	defaultConfirmInterval = 5 * time.Second
+)
+
+// MoveFloatingIPInput represents the input for moving a public IP between instances.
+type MoveFloatingIPInput struct {
+	IPAddress string
+	// InstanceID is the instance the IP is moved to.
+	InstanceID string
+	// ConfirmTimeout bounds how long the final assignment is polled for. It defaults to two minutes.
+	ConfirmTimeout time.Duration
+	// PollInterval defaults to five seconds.
+	PollInterval time.Duration
+}
+
+// FloatingIPStep represents a step taken while moving a public IP.
+type FloatingIPStep struct {
+	Action     string
+	InstanceID string
+	Started    time.Time
+	Duration   time.Duration
+	Err        string
+	// AuditErr holds the error writing the step to the audit log, which does not fail the step.
+	AuditErr string
+}
+
+// MoveFloatingIPOutput represents the output from moving a public IP between instances.
+type MoveFloatingIPOutput struct {
+	IPAddress      string
+	FromInstanceID string
+	ToInstanceID   string
+	RolledBack     bool
+	Steps          []FloatingIPStep
+	Duration       time.Duration
+	// AuditErr is the first error writing a step to the audit log. Audit failures are recorded
+	// here and in the step rather than failing the move.
+	AuditErr error
+}
+
+// MoveFloatingIP moves an additional public IP from the instance currently holding it to another
+// instance. When assigning to the target fails, the IP is assigned back to its original holder.
+// The final assignment is then confirmed. Every step is recorded in the output, which is returned
+// alongside any error, and in the client's audit log. Failures to write the audit log are reported
+// in the output's AuditErr.
+func (client *Client) MoveFloatingIP(ctx context.Context, input *MoveFloatingIPInput) (*MoveFloatingIPOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
+	if input.IPAddress == "" {
+		return nil, fmt.Errorf("atlantic: IP address must be provided")
+	}
+
+	if input.InstanceID == "" {
+		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
+	}
+
+	started := time.Now()
+	output := &MoveFloatingIPOutput{
+		IPAddress:    input.IPAddress,
+		ToInstanceID: input.InstanceID,
+	}
+	defer func() {
+		output.Duration = time.Since(started)
+	}()
+
+	err := output.step(client, "find-holder", "", func() error {
+		holder, err := client.floatingIPHolder(ctx, input.IPAddress)
+		output.FromInstanceID = holder
+		return err
+	})
+	if err != nil {
+		return output, err
+	}
+
+	if output.FromInstanceID == input.InstanceID {
+		return output, nil
+	}
+
+	if output.FromInstanceID != "" {
+		err := output.step(client, "unassign-public-ip", output.FromInstanceID, func() error {
+			_, err := client.unassignPublicIP(ctx, &UnassignPublicIPInput{IPAddress: []string{input.IPAddress}})
+			return err
+		})
+		if err != nil {
+			return output, err
+		}
+	}
+
+	expected := input.InstanceID
+
+	assignErr := output.step(client, "assign-public-ip", input.InstanceID, func() error {
+		_, err := client.assignPublicIP(ctx, &AssignPublicIPInput{
+			IPAddress:  []string{input.IPAddress},
+			InstanceID: input.InstanceID,
+		})
+		return err
+	})
+
+	if assignErr != nil {
+		if output.FromInstanceID == "" {
+			return output, assignErr
+		}
+
+		// The original context may be what failed the assignment, so the rollback gets its own.
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), defaultRollbackTimeout)
+		defer cancel()
+
+		err := output.step(client, "rollback-assign-public-ip", output.FromInstanceID, func() error {
+			_, err := client.assignPublicIP(rollbackCtx, &AssignPublicIPInput{
+				IPAddress:  []string{input.IPAddress},
+				InstanceID: output.FromInstanceID,
+			})
+			return err
+		})
+		if err != nil {
+			return output, fmt.Errorf("atlantic: assign failed (%v) and rollback failed: %v", assignErr, err)
+		}
+
+		output.RolledBack = true
+		expected = output.FromInstanceID
+		// The confirmation is bounded by ConfirmTimeout alone, rather than by what remains of
+		// the rollback's deadline.
+		ctx = context.Background()
+	}
+
+	err = output.step(client, "confirm", expected, func() error {
+		return client.confirmFloatingIP(ctx, input, expected)
+	})
+	if err != nil {
+		return output, err
+	}
+
+	if output.RolledBack {
+		return output, fmt.Errorf("atlantic: assign failed and was rolled back: %v", assignErr)
+	}
+
+	return output, nil
+}
+
+// step runs a single step of a move, recording it in the output and the client's audit log, and
+// returns the step's own error.
+func (output *MoveFloatingIPOutput) step(client *Client, action string, instanceID string, run func() error) error {
+	step := FloatingIPStep{
+		Action:     action,
+		InstanceID: instanceID,
+		Started:    time.Now(),
+	}
+
+	err := run()
+
+	step.Duration = time.Since(step.Started)
+	if err != nil {
+		step.Err = err.Error()
+	}
+
+	message := fmt.Sprintf("%s instance=%s duration=%s", action, instanceID, step.Duration)
+	if err != nil {
+		message += " error=" + step.Err
+	}
+
+	// The API call has already happened, so an audit failure must not change its outcome.
+	if auditErr := client.audit(AuditRecord{Action: "move-floating-ip", Target: output.IPAddress, Message: message}); auditErr != nil {
+		step.AuditErr = auditErr.Error()
+		if output.AuditErr == nil {
+			output.AuditErr = fmt.Errorf("atlantic: unable to record %s: %v", action, auditErr)
+		}
+	}
+
+	output.Steps = append(output.Steps, step)
+
+	return err
+}
+
+// floatingIPHolder returns the ID of the instance an additional public IP is assigned to, or an
+// empty string when it is unassigned.
+func (client *Client) floatingIPHolder(ctx context.Context, address string) (string, error) {
+	publicIPs, err := client.listPublicIPs(ctx, &ListPublicIPsInput{IPAddress: address})
+	if err != nil {
+		return "", err
+	}
+
+	for _, ip := range publicIPs.PublicIPs {
+		if ip.Address == address {
+			return ip.InstanceID, nil
+		}
+	}
+
+	return "", fmt.Errorf("atlantic: public IP %s not found", address)
+}
+
+// confirmFloatingIP polls until the IP is assigned to the expected instance.
+func (client *Client) confirmFloatingIP(ctx context.Context, input *MoveFloatingIPInput, expected string) error {
+	timeout := input.ConfirmTimeout
+	if timeout <= 0 {
+		timeout = defaultConfirmTimeout
+	}
+
+	interval := input.PollInterval
+	if interval <= 0 {
+		interval = defaultConfirmInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		holder, err := client.floatingIPHolder(ctx, input.IPAddress)
+		if err != nil {
+			return err
+		}
+
+		if holder == expected {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("atlantic: public IP %s is assigned to %q, expected %q: %v", input.IPAddress, holder, expected, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/kbrebanov/go-atlantic
 
-go 1.12
+go 1.18
 
 require github.com/satori/go.uuid v1.2.0
@@ -39,6 +39,10 @@ type DescribeImageOutput struct {
 
 // DescribeImage returns the description of a specific, or all, cloud images
 func (client *Client) DescribeImage(input *DescribeImageInput) (*DescribeImageOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "describe-image")
@@ -49,7 +53,7 @@ func (client *Client) DescribeImage(input *DescribeImageInput) (*DescribeImageOu
 
 	action := actionBuilder.String()
 
-	response, err := client.request(action)
+	response, err := client.catalogRequest(action)
 	if err != nil {
 		return nil, err
 	}
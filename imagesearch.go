@@ -0,0 +1,163 @@
+package atlantic
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ImageQuery represents the criteria for searching images. Empty criteria match every image,
+// and every comparison is case-insensitive.
+type ImageQuery struct {
+	OSType       string
+	Platform     string
+	Architecture string
+	Owner        string
+	// Name is a path.Match pattern matched against the display name, such as "Ubuntu*LTS*".
+	Name string
+}
+
+// FindImages returns the images matching the query, latest version first.
+func (client *Client) FindImages(query *ImageQuery) ([]Image, error) {
+	images, err := client.DescribeImage(&DescribeImageInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	ii := []Image{}
+	for _, i := range images.Images {
+		matched, err := query.matches(i)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			ii = append(ii, i)
+		}
+	}
+
+	sort.SliceStable(ii, func(a, b int) bool {
+		if c := CompareVersions(ii[a].Version, ii[b].Version); c != 0 {
+			return c > 0
+		}
+		return ii[a].DisplayName < ii[b].DisplayName
+	})
+
+	return ii, nil
+}
+
+// LatestImage returns the image with the highest version matching the query.
+func (client *Client) LatestImage(query *ImageQuery) (*Image, error) {
+	images, err := client.FindImages(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("atlantic: no image matches the query")
+	}
+
+	return &images[0], nil
+}
+
+// matches reports whether an image meets the query.
+func (query *ImageQuery) matches(i Image) (bool, error) {
+	for _, criterion := range []struct{ want, got string }{
+		{query.OSType, i.OSType},
+		{query.Platform, i.Platform},
+		{query.Architecture, i.Architecture},
+		{query.Owner, i.Owner},
+	} {
+		if criterion.want != "" && !strings.EqualFold(criterion.want, criterion.got) {
+			return false, nil
+		}
+	}
+
+	if query.Name == "" {
+		return true, nil
+	}
+
+	matched, err := path.Match(strings.ToLower(query.Name), strings.ToLower(i.DisplayName))
+	if err != nil {
+		return false, fmt.Errorf("atlantic: invalid image name pattern %q", query.Name)
+	}
+
+	return matched, nil
+}
+
+// CompareVersions compares two version strings, returning -1, 0 or 1. Numeric parts are
+// compared as numbers and other parts lexically, so "16.10" sorts after "16.4".
+func CompareVersions(a string, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.ParseUint(pa[i], 10, 64)
+		nb, errB := strconv.ParseUint(pb[i], 10, 64)
+
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			// Numeric parts sort after textual ones.
+			return 1
+		case errB == nil:
+			return -1
+		default:
+			if c := strings.Compare(strings.ToLower(pa[i]), strings.ToLower(pb[i])); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return 1
+	}
+
+	return 0
+}
+
+// versionParts splits a version into runs of digits and runs of letters, dropping separators.
+func versionParts(version string) []string {
+	var parts []string
+	var current []rune
+	digits := false
+
+	flush := func() {
+		if len(current) > 0 {
+			parts = append(parts, string(current))
+			current = nil
+		}
+	}
+
+	for _, r := range version {
+		switch {
+		case unicode.IsDigit(r):
+			if !digits {
+				flush()
+			}
+			digits = true
+			current = append(current, r)
+		case unicode.IsLetter(r):
+			if digits {
+				flush()
+			}
+			digits = false
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return parts
+}
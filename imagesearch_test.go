@@ -0,0 +1,35 @@
+package atlantic
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"16.04", "16.04", 0},
+		{"16.04", "16.10", -1},
+		{"16.10", "16.04", 1},
+		{"16.10", "18.04", -1},
+		{"16.4", "16.10", -1},
+		{"18.04", "18.04.1", -1},
+		{"7", "10", -1},
+		{"v2", "v10", -1},
+		{"v2", "V2", 0},
+		{"2008R2", "2012", -1},
+		{"2012R2", "2012", 1},
+		{"buster", "16.04", -1},
+		{"", "1", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := CompareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := CompareVersions(tt.b, tt.a); got != -tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
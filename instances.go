@@ -6,6 +6,12 @@ import (
 	"strings"
 )
 
+// Instance statuses reported in vm_status.
+const (
+	InstanceStatusRunning = "RUNNING"
+	InstanceStatusStopped = "STOPPED"
+)
+
 // ListInstancesResult represents the result from listing instances.
 type ListInstancesResult struct {
 	Timestamp int `json:"Timestamp"`
@@ -59,11 +65,18 @@ type TerminateInstance struct {
 // TerminateInstanceInput represents the input for terminating instances.
 type TerminateInstanceInput struct {
 	InstanceID []string
+	// DryRun lists the instances that would be terminated without terminating them.
+	DryRun bool
+	// Force overrides the client's termination policy. Overrides are recorded in the audit log
+	// and are refused when the client has none.
+	Force bool
 }
 
 // TerminateInstanceOutput represents the output from terminating instances.
 type TerminateInstanceOutput struct {
 	TerminateInstances []TerminateInstance
+	WouldTerminate     []string
+	Protected          []ProtectedInstance
 }
 
 // RunInstanceResult represents the result from running instances.
@@ -80,7 +93,7 @@ type RunInstance struct {
 	ID          string `json:"instanceid"`
 	IPAddress   string `json:"ip_address"`
 	IPv6Address string `json:"ipv6_address"`
-	Password    string `json:"password"`
+	Password    Secret `json:"password"`
 	Username    string `json:"username"`
 }
 
@@ -96,6 +109,8 @@ type RunInstanceInput struct {
 	Qty          int
 	Term         string
 	KeyID        string
+	// OverrideBudget skips the client's budget check.
+	OverrideBudget bool
 }
 
 // RunInstanceOutput represents the output from running instances.
@@ -136,6 +151,7 @@ type DescribeInstance struct {
 	VMIPv6Prefix                string `json:"vm_ipv6_prefix"`
 	VMIPv6PrefixUsable          string `json:"vm_ipv6_prefix_usable"`
 	VMIPv6Gateway               string `json:"vm_ipv6_gateway"`
+	VMName                      string `json:"vm_name"`
 	VMNetworkReq                string `json:"vm_network_req"`
 	VMOSArchitecture            string `json:"vm_os_architecture"`
 	VMPlanName                  string `json:"vm_plan_name"`
@@ -260,6 +276,8 @@ type ResizeInstance struct {
 type ResizeInstanceInput struct {
 	InstanceID string
 	PlanName   string
+	// OverrideBudget skips the client's budget check.
+	OverrideBudget bool
 }
 
 // ResizeInstanceOutput represents the output from resizing an instance.
@@ -286,7 +304,7 @@ type ReprovisionInstance struct {
 	} `json:"1instance"`
 	Item struct {
 		Username string `json:"username"`
-		Password string `json:"password"`
+		Password Secret `json:"password"`
 	} `json:"1item"`
 }
 
@@ -304,6 +322,14 @@ type ReprovisionInstanceOutput struct {
 
 // RunInstance creates one or more new instances.
 func (client *Client) RunInstance(input *RunInstanceInput) (*RunInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
+	if err := client.checkRunInstanceBudget(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "run-instance&servername=%s&imageid=%s&planname=%s&vm_location=%s", input.ServerName, input.ImageID, input.PlanName, input.Location)
@@ -391,6 +417,16 @@ func (client *Client) ListInstances() (*ListInstancesOutput, error) {
 
 // DescribeInstance retrieves the details of a specific instance.
 func (client *Client) DescribeInstance(input *DescribeInstanceInput) (*DescribeInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
+	return client.describeInstance(input)
+}
+
+// describeInstance retrieves the details of an instance by ID, without resolving names. It is
+// used internally wherever the ID is already known.
+func (client *Client) describeInstance(input *DescribeInstanceInput) (*DescribeInstanceOutput, error) {
 	if input.InstanceID == "" {
 		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
 	}
@@ -419,6 +455,10 @@ func (client *Client) DescribeInstance(input *DescribeInstanceInput) (*DescribeI
 
 // RebootInstance reboots a specific instance.
 func (client *Client) RebootInstance(input *RebootInstanceInput) (*RebootInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	if input.InstanceID == "" {
@@ -455,6 +495,10 @@ func (client *Client) RebootInstance(input *RebootInstanceInput) (*RebootInstanc
 
 // ShutdownInstance shuts down one or more instances.
 func (client *Client) ShutdownInstance(input *ShutdownInstanceInput) (*ShutdownInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 	var instancesBuilder strings.Builder
 	var instances string
@@ -507,6 +551,10 @@ func (client *Client) ShutdownInstance(input *ShutdownInstanceInput) (*ShutdownI
 
 // PowerOnInstance power's on one or more instances.
 func (client *Client) PowerOnInstance(input *PowerOnInstanceInput) (*PowerOnInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var instancesBuilder strings.Builder
 	var instances string
 
@@ -550,6 +598,10 @@ func (client *Client) PowerOnInstance(input *PowerOnInstanceInput) (*PowerOnInst
 
 // ResizeInstance resizes an instance to a larger plan.
 func (client *Client) ResizeInstance(input *ResizeInstanceInput) (*ResizeInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	if input.InstanceID == "" {
 		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
 	}
@@ -558,6 +610,10 @@ func (client *Client) ResizeInstance(input *ResizeInstanceInput) (*ResizeInstanc
 		return nil, fmt.Errorf("atlantic: Plan name must be provided")
 	}
 
+	if err := client.checkResizeInstanceBudget(input); err != nil {
+		return nil, err
+	}
+
 	action := fmt.Sprintf("resize-instance&instanceid=%s&planname=%s", input.InstanceID, input.PlanName)
 
 	response, err := client.request(action)
@@ -582,6 +638,10 @@ func (client *Client) ResizeInstance(input *ResizeInstanceInput) (*ResizeInstanc
 
 // ReprovisionInstance reprovisions (rebuilds) an instance with the same or different specifications.
 func (client *Client) ReprovisionInstance(input *ReprovisionInstanceInput) (*ReprovisionInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	if input.InstanceID == "" {
 		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
 	}
@@ -618,6 +678,10 @@ func (client *Client) ReprovisionInstance(input *ReprovisionInstanceInput) (*Rep
 
 // TerminateInstance removes one or more instances.
 func (client *Client) TerminateInstance(input *TerminateInstanceInput) (*TerminateInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var instancesBuilder strings.Builder
 	var instances string
 
@@ -634,6 +698,42 @@ func (client *Client) TerminateInstance(input *TerminateInstanceInput) (*Termina
 		instances = strings.TrimRight(instancesBuilder.String(), "&")
 	}
 
+	check, err := client.checkTermination(input.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DryRun {
+		output := &TerminateInstanceOutput{
+			WouldTerminate: check.Allowed,
+			Protected:      check.Protected,
+		}
+		if input.Force && client.refuse(check, input.Force) == nil {
+			for _, i := range check.Protected {
+				output.WouldTerminate = append(output.WouldTerminate, i.ID)
+			}
+		}
+		return output, nil
+	}
+
+	if err := client.refuse(check, input.Force); err != nil {
+		return nil, err
+	}
+
+	if len(check.Protected) > 0 {
+		for _, i := range check.Protected {
+			record := AuditRecord{
+				Action:  "terminate-instance",
+				Target:  i.ID,
+				Message: fmt.Sprintf("termination policy overridden for %s: %s", i.Name, i.Reason),
+				Forced:  true,
+			}
+			if err := client.audit(record); err != nil {
+				return nil, fmt.Errorf("atlantic: unable to record termination override: %v", err)
+			}
+		}
+	}
+
 	action := fmt.Sprintf("terminate-instance&%s", instances)
 
 	response, err := client.request(action)
@@ -0,0 +1,33 @@
+package atlantic
+
+import "sort"
+
+// describeInstances retrieves the details of every active instance on the account, ordered by ID.
+func (client *Client) describeInstances() ([]DescribeInstance, error) {
+	instances, err := client.ListInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	ii := []DescribeInstance{}
+	for _, listed := range instances.ListInstances {
+		described, err := client.describeInstance(&DescribeInstanceInput{InstanceID: listed.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		i := described.DescribeInstance
+		if i.ID == "" {
+			i.ID = listed.ID
+		}
+		if i.VMName == "" {
+			i.VMName = listed.Name
+		}
+
+		ii = append(ii, i)
+	}
+
+	sort.Slice(ii, func(a, b int) bool { return ii[a].ID < ii[b].ID })
+
+	return ii, nil
+}
@@ -0,0 +1,246 @@
+package atlantic
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// IPPool manages a pool of additional public IPs in a location. The pool only ever allocates,
+// returns and releases its own IPs: the IPs it reserved and the IPs adopted into it. IPs are
+// allocated from the pool's free IPs before new ones are reserved, and returned IPs stay reserved
+// for reuse. Call AdoptFree to take over the location's existing free IPs.
+type IPPool struct {
+	client *Client
+
+	// Location is the code of the location the pool manages.
+	Location string
+	// Spare is the number of free reserved IPs Reconcile keeps available.
+	Spare int
+
+	mu      sync.Mutex
+	members map[string]bool
+}
+
+// IPPoolReconcileOutput represents the changes made when reconciling a pool.
+type IPPoolReconcileOutput struct {
+	Reserved []string
+	Released []string
+	Free     []string
+	Assigned []string
+}
+
+// NewIPPool returns a pool managing the additional public IPs of a location.
+func NewIPPool(client *Client, location string, spare int) *IPPool {
+	return &IPPool{
+		client:   client,
+		Location: location,
+		Spare:    spare,
+		members:  map[string]bool{},
+	}
+}
+
+// Adopt adds IPs already reserved on the account to the pool, such as the members of a pool
+// saved from a previous run. Adopted IPs may be allocated and released by the pool.
+func (pool *IPPool) Adopt(addresses ...string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.members == nil {
+		pool.members = map[string]bool{}
+	}
+
+	for _, address := range addresses {
+		pool.members[address] = true
+	}
+}
+
+// AdoptFree adds every IP reserved in the pool's location and assigned to no instance to the
+// pool, and returns the adopted addresses. It suits a pool that owns all of the location's free
+// IPs. Note that an IP briefly unassigned by another process, such as MoveFloatingIP, is adopted too.
+func (pool *IPPool) AdoptFree() ([]string, error) {
+	if pool.Location == "" {
+		return nil, fmt.Errorf("atlantic: Location must be provided")
+	}
+
+	publicIPs, err := pool.client.ListPublicIPs(&ListPublicIPsInput{Location: pool.Location})
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := []string{}
+	for _, ip := range publicIPs.PublicIPs {
+		if ip.InstanceID == "" {
+			adopted = append(adopted, ip.Address)
+		}
+	}
+	sort.Strings(adopted)
+
+	pool.Adopt(adopted...)
+
+	return adopted, nil
+}
+
+// Members returns the addresses of the pool's IPs, sorted.
+func (pool *IPPool) Members() []string {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	members := []string{}
+	for address := range pool.members {
+		members = append(members, address)
+	}
+	sort.Strings(members)
+
+	return members
+}
+
+// list returns the pool's free and assigned IPs, sorted by address. Members that are no longer
+// reserved on the account are dropped from the pool.
+func (pool *IPPool) list() (free []string, assigned []string, err error) {
+	if pool.Location == "" {
+		return nil, nil, fmt.Errorf("atlantic: Location must be provided")
+	}
+
+	if pool.members == nil {
+		pool.members = map[string]bool{}
+	}
+
+	publicIPs, err := pool.client.ListPublicIPs(&ListPublicIPsInput{Location: pool.Location})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reserved := map[string]bool{}
+	for _, ip := range publicIPs.PublicIPs {
+		if !pool.members[ip.Address] {
+			continue
+		}
+		reserved[ip.Address] = true
+
+		if ip.InstanceID == "" {
+			free = append(free, ip.Address)
+		} else {
+			assigned = append(assigned, ip.Address)
+		}
+	}
+
+	for address := range pool.members {
+		if !reserved[address] {
+			delete(pool.members, address)
+		}
+	}
+
+	sort.Strings(free)
+	sort.Strings(assigned)
+
+	return free, assigned, nil
+}
+
+// Acquire assigns an IP from the pool to an instance, reserving a new IP only when no reserved IP
+// is free, and returns its address.
+func (pool *IPPool) Acquire(instanceID string) (string, error) {
+	if instanceID == "" {
+		return "", fmt.Errorf("atlantic: Instance ID must be provided")
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	free, _, err := pool.list()
+	if err != nil {
+		return "", err
+	}
+
+	var address string
+	if len(free) > 0 {
+		address = free[0]
+	} else {
+		reserved, err := pool.client.ReservePublicIP(&ReservePublicIPInput{Location: pool.Location, Qty: 1})
+		if err != nil {
+			return "", err
+		}
+		if len(reserved.ReservePublicIPs) == 0 || reserved.ReservePublicIPs[0].Address == "" {
+			return "", fmt.Errorf("atlantic: no public IP reserved in %s", pool.Location)
+		}
+		address = reserved.ReservePublicIPs[0].Address
+		pool.members[address] = true
+	}
+
+	_, err = pool.client.AssignPublicIP(&AssignPublicIPInput{
+		IPAddress:  []string{address},
+		InstanceID: instanceID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+// Return unassigns an IP of the pool from its instance and keeps it reserved in the pool.
+func (pool *IPPool) Return(address string) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if !pool.members[address] {
+		return fmt.Errorf("atlantic: public IP %s does not belong to the pool", address)
+	}
+
+	_, err := pool.client.UnassignPublicIP(&UnassignPublicIPInput{IPAddress: []string{address}})
+	return err
+}
+
+// Reconcile reserves or releases IPs of the pool so that exactly Spare of them are free. IPs
+// outside the pool are never released.
+func (pool *IPPool) Reconcile() (*IPPoolReconcileOutput, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.Spare < 0 {
+		return nil, fmt.Errorf("atlantic: Spare must not be negative")
+	}
+
+	free, assigned, err := pool.list()
+	if err != nil {
+		return nil, err
+	}
+
+	output := &IPPoolReconcileOutput{Assigned: assigned}
+
+	switch {
+	case len(free) < pool.Spare:
+		reserved, err := pool.client.ReservePublicIP(&ReservePublicIPInput{
+			Location: pool.Location,
+			Qty:      pool.Spare - len(free),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range reserved.ReservePublicIPs {
+			pool.members[ip.Address] = true
+			output.Reserved = append(output.Reserved, ip.Address)
+			free = append(free, ip.Address)
+		}
+
+	case len(free) > pool.Spare:
+		excess := free[pool.Spare:]
+
+		if _, err := pool.client.ReleasePublicIP(&ReleasePublicIPInput{IPAddress: excess}); err != nil {
+			return nil, err
+		}
+
+		for _, address := range excess {
+			delete(pool.members, address)
+		}
+
+		output.Released = excess
+		free = free[:pool.Spare]
+	}
+
+	sort.Strings(free)
+	output.Free = free
+
+	return output, nil
+}
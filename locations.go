@@ -29,7 +29,7 @@ type ListLocationsOutput struct {
 func (client *Client) ListLocations() (*ListLocationsOutput, error) {
 	action := "list-locations"
 
-	response, err := client.request(action)
+	response, err := client.catalogRequest(action)
 	if err != nil {
 		return nil, err
 	}
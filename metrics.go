@@ -0,0 +1,161 @@
+package atlantic
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InventoryMetricsHandler serves gauges describing the account's instances, spend and public IPs
+// in the Prometheus text exposition format. The inventory is retrieved on every scrape.
+type InventoryMetricsHandler struct {
+	client *Client
+}
+
+// NewInventoryMetricsHandler returns a handler exposing inventory metrics for the client's account.
+func NewInventoryMetricsHandler(client *Client) *InventoryMetricsHandler {
+	return &InventoryMetricsHandler{client: client}
+}
+
+// ServeHTTP writes the inventory metrics.
+func (handler *InventoryMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := handler.client.WriteInventoryMetrics(&buf); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// WriteInventoryMetrics writes the inventory metrics in the Prometheus text exposition format.
+func (client *Client) WriteInventoryMetrics(w io.Writer) error {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return err
+	}
+
+	publicIPs, err := client.ListPublicIPs(&ListPublicIPsInput{})
+	if err != nil {
+		return err
+	}
+
+	var metrics []*gauge
+
+	counts := newGauge("atlantic_instances", "Number of instances by status, plan and location.")
+	spend := newGauge("atlantic_hourly_spend_dollars", "Total hourly rate of all instances.")
+	bytesIn := newGauge("atlantic_instance_bytes_in", "Inbound transfer of an instance.")
+	bytesOut := newGauge("atlantic_instance_bytes_out", "Outbound transfer of an instance.")
+	bytesInIncluded := newGauge("atlantic_instance_bytes_in_included", "Inbound transfer included in the plan of an instance.")
+	bytesOutIncluded := newGauge("atlantic_instance_bytes_out_included", "Outbound transfer included in the plan of an instance.")
+
+	spend.add(nil, 0)
+	for _, i := range instances {
+		counts.add([]string{"status", i.VMStatus, "plan", i.VMPlanName, "location", i.VMLocation}, 1)
+
+		if rate, err := parseNumber(i.RatePerHour); err == nil {
+			spend.add(nil, rate)
+		}
+
+		labels := []string{"instance_id", i.ID, "name", i.VMName}
+		for _, m := range []struct {
+			g     *gauge
+			value string
+		}{
+			{bytesIn, i.BytesIn},
+			{bytesOut, i.BytesOut},
+			{bytesInIncluded, i.BytesInIncluded},
+			{bytesOutIncluded, i.BytesOutIncluded},
+		} {
+			if value, err := parseNumber(m.value); err == nil {
+				m.g.add(labels, value)
+			}
+		}
+	}
+
+	reserved := newGauge("atlantic_public_ips_reserved", "Number of additional public IPs reserved by location.")
+	assigned := newGauge("atlantic_public_ips_assigned", "Number of additional public IPs assigned to an instance by location.")
+
+	for _, ip := range publicIPs.PublicIPs {
+		labels := []string{"location", ip.Location}
+		reserved.add(labels, 1)
+		if ip.InstanceID != "" {
+			assigned.add(labels, 1)
+		} else {
+			assigned.add(labels, 0)
+		}
+	}
+
+	metrics = append(metrics, counts, spend, reserved, assigned, bytesIn, bytesOut, bytesInIncluded, bytesOutIncluded)
+
+	for _, m := range metrics {
+		if _, err := m.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gauge accumulates the samples of a single gauge metric family.
+type gauge struct {
+	name    string
+	help    string
+	samples map[string]float64
+}
+
+func newGauge(name string, help string) *gauge {
+	return &gauge{name: name, help: help, samples: map[string]float64{}}
+}
+
+// add adds value to the sample identified by labels, given as alternating names and values.
+func (g *gauge) add(labels []string, value float64) {
+	var pairs []string
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1])))
+	}
+
+	key := ""
+	if len(pairs) > 0 {
+		key = "{" + strings.Join(pairs, ",") + "}"
+	}
+
+	g.samples[key] += value
+}
+
+// WriteTo writes the metric family in the text exposition format.
+func (g *gauge) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# HELP %s %s\n", g.name, g.help)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", g.name)
+
+	keys := make([]string, 0, len(g.samples))
+	for k := range g.samples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s%s %s\n", g.name, k, strconv.FormatFloat(g.samples[k], 'g', -1, 64))
+	}
+
+	return b.WriteTo(w)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value for the text exposition format.
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+// parseNumber parses a numeric value returned by the API as a string.
+func parseNumber(value string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(value), 64)
+}
@@ -27,7 +27,7 @@ type ListPrivateNetworksOutput struct {
 func (client *Client) ListPrivateNetworks() (*ListPrivateNetworksOutput, error) {
 	action := "list-private-networks"
 
-	response, err := client.request(action)
+	response, err := client.catalogRequest(action)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +47,10 @@ type DescribePlanOutput struct {
 
 // DescribePlan returns the description of all, or a specific, server plans.
 func (client *Client) DescribePlan(input *DescribePlanInput) (*DescribePlanOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "describe-plan")
@@ -61,7 +65,7 @@ func (client *Client) DescribePlan(input *DescribePlanInput) (*DescribePlanOutpu
 
 	action := actionBuilder.String()
 
-	response, err := client.request(action)
+	response, err := client.catalogRequest(action)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,194 @@
+package atlantic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Sizes, in bytes, for expressing plan requirements.
+const (
+	Megabyte int64 = 1 << 20
+	Gigabyte int64 = 1 << 30
+	Terabyte int64 = 1 << 40
+)
+
+// PlanRequirements represents the minimum resources and capabilities a plan must provide.
+type PlanRequirements struct {
+	MinCPU int
+	// MinRAM, MinDisk and MinTransfer are in bytes.
+	MinRAM      int64
+	MinDisk     int64
+	MinTransfer int64
+	Platform    string
+	Windows     bool
+	CPanel      bool
+	CentOS      bool
+	// Term ranks plans by their rate for a "1" or "3" year term instead of the on-demand rate.
+	Term string
+}
+
+// PlanChoice represents a plan meeting a set of requirements. Resources the requirements do not
+// constrain are zero when the plan reports them in a form that cannot be parsed.
+type PlanChoice struct {
+	Plan        Plan
+	CPU         int
+	RAM         int64
+	Disk        int64
+	Transfer    int64
+	RatePerHour float64
+}
+
+// SelectPlan returns the unlocked plans meeting the requirements, cheapest first by their
+// effective hourly rate for the requested term. Plans are only excluded for values that cannot
+// be parsed when the requirements constrain them.
+func (client *Client) SelectPlan(requirements *PlanRequirements) ([]PlanChoice, error) {
+	plans, err := client.DescribePlan(&DescribePlanInput{Platform: requirements.Platform})
+	if err != nil {
+		return nil, err
+	}
+
+	choices := []PlanChoice{}
+	var parseErrs []string
+	for _, p := range plans.Plans {
+		choice, err := requirements.newPlanChoice(p)
+		if err != nil {
+			parseErrs = append(parseErrs, err.Error())
+			continue
+		}
+
+		if requirements.satisfiedBy(choice) {
+			choices = append(choices, choice)
+		}
+	}
+
+	if len(choices) == 0 {
+		if len(parseErrs) > 0 {
+			return nil, fmt.Errorf("atlantic: no plan meets the requirements, %d plans could not be evaluated: %s",
+				len(parseErrs), strings.Join(parseErrs, "; "))
+		}
+		return nil, fmt.Errorf("atlantic: no plan meets the requirements")
+	}
+
+	sort.SliceStable(choices, func(a, b int) bool {
+		if choices[a].RatePerHour != choices[b].RatePerHour {
+			return choices[a].RatePerHour < choices[b].RatePerHour
+		}
+		return choices[a].Plan.Name < choices[b].Plan.Name
+	})
+
+	return choices, nil
+}
+
+// newPlanChoice parses the resources and rate of a plan. Values that cannot be parsed are an
+// error for the rate, which ranks every plan, and for the resources the requirements constrain.
+func (requirements *PlanRequirements) newPlanChoice(p Plan) (PlanChoice, error) {
+	cpu, err := strconv.Atoi(strings.TrimSpace(p.NumCPU))
+	if err != nil && requirements.MinCPU > 0 {
+		return PlanChoice{}, fmt.Errorf("atlantic: invalid CPU count %q for plan %s", p.NumCPU, p.Name)
+	}
+
+	ram, err := parseSize(p.DisplayRAM, Gigabyte)
+	if err != nil && requirements.MinRAM > 0 {
+		return PlanChoice{}, fmt.Errorf("atlantic: invalid RAM %q for plan %s", p.DisplayRAM, p.Name)
+	}
+
+	disk, err := parseSize(p.DisplayDisk, Gigabyte)
+	if err != nil && requirements.MinDisk > 0 {
+		return PlanChoice{}, fmt.Errorf("atlantic: invalid disk %q for plan %s", p.DisplayDisk, p.Name)
+	}
+
+	// Free transfer is reported in terabytes when it carries no unit.
+	transfer, err := parseSize(p.FreeTransfer, Terabyte)
+	if err != nil && requirements.MinTransfer > 0 {
+		return PlanChoice{}, fmt.Errorf("atlantic: invalid transfer %q for plan %s", p.FreeTransfer, p.Name)
+	}
+
+	rate, err := p.Rate(requirements.Term)
+	if err != nil {
+		return PlanChoice{}, err
+	}
+
+	return PlanChoice{
+		Plan:        p,
+		CPU:         cpu,
+		RAM:         ram,
+		Disk:        disk,
+		Transfer:    transfer,
+		RatePerHour: rate,
+	}, nil
+}
+
+// satisfiedBy reports whether a plan choice meets the requirements.
+func (requirements *PlanRequirements) satisfiedBy(choice PlanChoice) bool {
+	p := choice.Plan
+
+	if isFlagSet(p.Locked) {
+		return false
+	}
+
+	if choice.CPU < requirements.MinCPU || choice.RAM < requirements.MinRAM ||
+		choice.Disk < requirements.MinDisk || choice.Transfer < requirements.MinTransfer {
+		return false
+	}
+
+	if requirements.Platform != "" && p.Platform != "" && !strings.EqualFold(p.Platform, requirements.Platform) {
+		return false
+	}
+
+	if requirements.Windows && !isFlagSet(p.WindowsCapable) ||
+		requirements.CPanel && !isFlagSet(p.CPanelCapable) ||
+		requirements.CentOS && !isFlagSet(p.CentOSCapable) {
+		return false
+	}
+
+	return true
+}
+
+// ParseSize parses a display size such as "512MB", "2GB", "1.5 TB" or "100" into bytes.
+// Units are binary, and values without a unit are taken to be in gigabytes.
+func ParseSize(value string) (int64, error) {
+	return parseSize(value, Gigabyte)
+}
+
+// parseSize parses a display size into bytes, applying defaultUnit to values without a unit.
+func parseSize(value string, defaultUnit int64) (int64, error) {
+	s := strings.ToUpper(strings.Join(strings.Fields(value), ""))
+	s = strings.Replace(s, ",", "", -1)
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], s[i:]
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("atlantic: invalid size %q", value)
+	}
+
+	multiplier := defaultUnit
+	switch strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I") {
+	case "":
+		if unit == "B" {
+			multiplier = 1
+		}
+	case "K":
+		multiplier = 1 << 10
+	case "M":
+		multiplier = Megabyte
+	case "G":
+		multiplier = Gigabyte
+	case "T":
+		multiplier = Terabyte
+	default:
+		return 0, fmt.Errorf("atlantic: invalid size %q", value)
+	}
+
+	return int64(n * float64(multiplier)), nil
+}
@@ -0,0 +1,55 @@
+package atlantic
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"512MB", 512 * Megabyte},
+		{"512 MB", 512 * Megabyte},
+		{"1,024 MB", Gigabyte},
+		{"2GB", 2 * Gigabyte},
+		{"2GiB", 2 * Gigabyte},
+		{"2 gib", 2 * Gigabyte},
+		{"1.5 TB", Terabyte + Terabyte/2},
+		{"1TiB", Terabyte},
+		{"4K", 4 << 10},
+		{"100", 100 * Gigabyte},
+		{"0.5", Gigabyte / 2},
+		{"512B", 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSize(tt.value)
+			if err != nil {
+				t.Fatalf("ParseSize(%q) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSizeDefaultUnit(t *testing.T) {
+	got, err := parseSize("5", Terabyte)
+	if err != nil {
+		t.Fatalf("parseSize() error = %v", err)
+	}
+	if got != 5*Terabyte {
+		t.Errorf("parseSize() = %d, want %d", got, 5*Terabyte)
+	}
+}
+
+func TestParseSizeInvalid(t *testing.T) {
+	for _, value := range []string{"", "Unlimited", "GB", "-1GB", "10XB", "1.2.3GB"} {
+		t.Run(value, func(t *testing.T) {
+			if got, err := ParseSize(value); err == nil {
+				t.Errorf("ParseSize(%q) = %d, want an error", value, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package atlantic
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+)
+
+// maxAllocationTableSize bounds the number of addresses an allocation table covers.
+const maxAllocationTableSize = 1 << 16
+
+// AllocationTable represents the used and free addresses of a private network. Tables of networks
+// larger than 65536 addresses only cover the start of the usable range and are marked Truncated.
+type AllocationTable struct {
+	Network PrivateNetwork
+	Prefix  netip.Prefix
+	First   netip.Addr
+	Last    netip.Addr
+	Used    []netip.Addr
+	Free    []netip.Addr
+	// Truncated reports whether addresses after Last were left out of the table.
+	Truncated bool
+}
+
+// Contains reports whether an address belongs to the private network.
+func (pn PrivateNetwork) Contains(addr netip.Addr) (bool, error) {
+	prefix, err := pn.NetworkPrefix()
+	if err != nil {
+		return false, err
+	}
+	return prefix.Contains(addr), nil
+}
+
+// OverlappingNetworks returns the private networks that overlap a proposed subnet. A single
+// address can be checked by passing a host prefix such as 10.0.0.5/32.
+func OverlappingNetworks(networks []PrivateNetwork, proposed netip.Prefix) ([]PrivateNetwork, error) {
+	overlapping := []PrivateNetwork{}
+	for _, pn := range networks {
+		prefix, err := pn.NetworkPrefix()
+		if err != nil {
+			return nil, err
+		}
+
+		if prefix.Overlaps(proposed.Masked()) {
+			overlapping = append(overlapping, pn)
+		}
+	}
+	return overlapping, nil
+}
+
+// NewAllocationTable returns the allocation table of a private network given the addresses in
+// use. Addresses outside the network are ignored. The usable range is the network's IP range when
+// it has one, and otherwise its prefix without the network and broadcast addresses.
+func NewAllocationTable(pn PrivateNetwork, used []netip.Addr) (*AllocationTable, error) {
+	prefix, err := pn.NetworkPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	first, last, err := pn.usableRange(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := false
+	end := first
+	for count := 1; count < maxAllocationTableSize && end.Less(last); count++ {
+		end = end.Next()
+	}
+	if end.Less(last) {
+		last, truncated = end, true
+	}
+
+	table := &AllocationTable{
+		Network:   pn,
+		Prefix:    prefix,
+		First:     first,
+		Last:      last,
+		Used:      []netip.Addr{},
+		Free:      []netip.Addr{},
+		Truncated: truncated,
+	}
+
+	inUse := map[netip.Addr]bool{}
+	for _, addr := range used {
+		addr = addr.Unmap()
+		if !inUse[addr] && !addr.Less(first) && !last.Less(addr) {
+			inUse[addr] = true
+			table.Used = append(table.Used, addr)
+		}
+	}
+	sort.Slice(table.Used, func(a, b int) bool { return table.Used[a].Less(table.Used[b]) })
+
+	for addr := first; addr.IsValid() && !last.Less(addr); addr = addr.Next() {
+		if !inUse[addr] {
+			table.Free = append(table.Free, addr)
+		}
+	}
+
+	return table, nil
+}
+
+// usableRange returns the first and last assignable addresses of the private network.
+func (pn PrivateNetwork) usableRange(prefix netip.Prefix) (netip.Addr, netip.Addr, error) {
+	if pn.IPRange != "" {
+		return pn.Range()
+	}
+
+	first, last := prefix.Addr(), lastAddr(prefix)
+	if first.Is4() && prefix.Bits() < 31 {
+		first, last = first.Next(), last.Prev()
+	}
+
+	return first, last, nil
+}
+
+// Next returns the lowest free address in the table.
+func (table *AllocationTable) Next() (netip.Addr, bool) {
+	if len(table.Free) == 0 {
+		return netip.Addr{}, false
+	}
+	return table.Free[0], true
+}
+
+// WriteTo writes the table, one address and its state per line.
+func (table *AllocationTable) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	used := map[netip.Addr]bool{}
+	for _, addr := range table.Used {
+		used[addr] = true
+	}
+
+	fmt.Fprintf(&b, "# %s (%s - %s): %d used, %d free\n", table.Prefix, table.First, table.Last, len(table.Used), len(table.Free))
+	for addr := table.First; addr.IsValid() && !table.Last.Less(addr); addr = addr.Next() {
+		state := "free"
+		if used[addr] {
+			state = "used"
+		}
+		fmt.Fprintf(&b, "%s\t%s\n", addr, state)
+	}
+	if table.Truncated {
+		fmt.Fprintf(&b, "# truncated after %s\n", table.Last)
+	}
+
+	return b.WriteTo(w)
+}
+
+// PrivateAllocationTables returns the allocation table of every private network on the account,
+// treating the given addresses as used. The API does not report the private addresses of
+// instances, so the addresses in use must come from the caller's own records.
+func (client *Client) PrivateAllocationTables(used []netip.Addr) ([]*AllocationTable, error) {
+	networks, err := client.ListPrivateNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := []*AllocationTable{}
+	for _, pn := range networks.PrivateNetworks {
+		table, err := NewAllocationTable(pn, used)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
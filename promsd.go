@@ -0,0 +1,203 @@
+package atlantic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPrometheusLabelPrefix prefixes the labels attached to discovered targets, which
+	// Prometheus keeps as target labels.
+	DefaultPrometheusLabelPrefix = "atlantic_"
+	// MetaPrometheusLabelPrefix exposes the labels as meta labels instead, which Prometheus only
+	// offers to relabeling rules and drops afterwards.
+	MetaPrometheusLabelPrefix = "__meta_atlantic_"
+
+	defaultPrometheusPort       = 9100
+	defaultPrometheusSDInterval = 5 * time.Minute
+)
+
+// PrometheusTargetGroup represents a group of targets in Prometheus file_sd and http_sd format.
+type PrometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// PrometheusSDOptions represents the options for generating Prometheus targets.
+type PrometheusSDOptions struct {
+	// Ports lists the ports scraped on every instance. It defaults to 9100.
+	Ports []int
+	// LabelPrefix defaults to DefaultPrometheusLabelPrefix. Set it to MetaPrometheusLabelPrefix
+	// to select labels with relabeling rules instead.
+	LabelPrefix string
+	// PreferIPv6 targets the IPv6 address when the instance has one.
+	PreferIPv6 bool
+}
+
+// PrometheusTargets returns a target group for every active instance.
+func (client *Client) PrometheusTargets(options *PrometheusSDOptions) ([]PrometheusTargetGroup, error) {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrometheusTargets(instances, options), nil
+}
+
+// NewPrometheusTargets returns a target group for each instance, labelled with its ID, name,
+// plan, location, image and status. Instances without an address are skipped.
+func NewPrometheusTargets(instances []DescribeInstance, options *PrometheusSDOptions) []PrometheusTargetGroup {
+	if options == nil {
+		options = &PrometheusSDOptions{}
+	}
+
+	ports := options.Ports
+	if len(ports) == 0 {
+		ports = []int{defaultPrometheusPort}
+	}
+
+	prefix := options.LabelPrefix
+	if prefix == "" {
+		prefix = DefaultPrometheusLabelPrefix
+	}
+
+	groups := []PrometheusTargetGroup{}
+	for _, i := range instances {
+		host := i.VMIPAddress
+		if (options.PreferIPv6 || host == "") && i.VMIPv6Address != "" {
+			host = i.VMIPv6Address
+		}
+		if host == "" {
+			continue
+		}
+
+		group := PrometheusTargetGroup{
+			Labels: map[string]string{
+				prefix + "instance_id": i.ID,
+				prefix + "name":        i.VMName,
+				prefix + "plan":        i.VMPlanName,
+				prefix + "location":    i.VMLocation,
+				prefix + "image":       i.VMImage,
+				prefix + "status":      i.VMStatus,
+			},
+		}
+
+		for _, port := range ports {
+			group.Targets = append(group.Targets, net.JoinHostPort(host, strconv.Itoa(port)))
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// WritePrometheusFileSD atomically writes target groups to filename in file_sd JSON format.
+func WritePrometheusFileSD(filename string, groups []PrometheusTargetGroup) error {
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, append(data, '\n'), 0644)
+}
+
+// PrometheusSDHandler serves target groups in http_sd format. Targets are refreshed from the
+// API in the background every interval, and the last good snapshot is served while the API fails.
+type PrometheusSDHandler struct {
+	client   *Client
+	options  *PrometheusSDOptions
+	interval time.Duration
+
+	mu      sync.Mutex
+	groups  []PrometheusTargetGroup
+	lastErr error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPrometheusSDHandler returns an http_sd handler refreshing targets every interval until it is
+// closed. The first refresh starts immediately.
+func NewPrometheusSDHandler(client *Client, options *PrometheusSDOptions, interval time.Duration) *PrometheusSDHandler {
+	if interval <= 0 {
+		interval = defaultPrometheusSDInterval
+	}
+
+	handler := &PrometheusSDHandler{
+		client:   client,
+		options:  options,
+		interval: interval,
+		lastErr:  fmt.Errorf("atlantic: targets have not been retrieved yet"),
+		stop:     make(chan struct{}),
+	}
+
+	go handler.run()
+
+	return handler
+}
+
+// run refreshes the snapshot every interval until the handler is closed.
+func (handler *PrometheusSDHandler) run() {
+	ticker := time.NewTicker(handler.interval)
+	defer ticker.Stop()
+
+	for {
+		handler.refresh()
+
+		select {
+		case <-ticker.C:
+		case <-handler.stop:
+			return
+		}
+	}
+}
+
+// refresh retrieves the targets from the API, keeping the previous snapshot on failure.
+func (handler *PrometheusSDHandler) refresh() {
+	groups, err := handler.client.PrometheusTargets(handler.options)
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	handler.lastErr = err
+	if err == nil {
+		handler.groups = groups
+	}
+}
+
+// Close stops the background refresh.
+func (handler *PrometheusSDHandler) Close() error {
+	handler.stopOnce.Do(func() { close(handler.stop) })
+	return nil
+}
+
+// Targets returns the current snapshot. It only returns an error when no snapshot has ever been
+// retrieved.
+func (handler *PrometheusSDHandler) Targets() ([]PrometheusTargetGroup, error) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	if handler.groups == nil {
+		return nil, handler.lastErr
+	}
+
+	return handler.groups, nil
+}
+
+// ServeHTTP writes the current target groups as JSON.
+func (handler *PrometheusSDHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groups, err := handler.Targets()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
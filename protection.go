@@ -0,0 +1,136 @@
+package atlantic
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// TerminationPolicy represents the local rules that guard instances against termination.
+// When a policy is set on the client, TerminateInstance refuses to remove instances flagged
+// disallow_deletion, instances whose name matches a protected pattern and instances on the deny list.
+type TerminationPolicy struct {
+	// ProtectedNames holds path.Match patterns matched against instance names.
+	ProtectedNames []string
+	// DenyList holds the IDs of instances that must never be terminated.
+	DenyList []string
+}
+
+// ProtectedInstance represents an instance that a termination policy refuses to terminate.
+type ProtectedInstance struct {
+	ID     string
+	Name   string
+	Reason string
+}
+
+// TerminationCheck represents the outcome of checking instances against a termination policy.
+type TerminationCheck struct {
+	Allowed   []string
+	Protected []ProtectedInstance
+}
+
+// TerminationProtectedError is returned when a termination request includes protected instances.
+type TerminationProtectedError struct {
+	Instances []ProtectedInstance
+}
+
+func (e *TerminationProtectedError) Error() string {
+	var reasons []string
+	for _, i := range e.Instances {
+		reasons = append(reasons, fmt.Sprintf("%s (%s): %s", i.ID, i.Name, i.Reason))
+	}
+	return fmt.Sprintf("atlantic: termination refused for protected instances: %s", strings.Join(reasons, "; "))
+}
+
+// CheckTermination checks the given instances against the client's termination policy.
+// Every instance is allowed when no policy is set.
+func (client *Client) CheckTermination(instanceIDs []string) (*TerminationCheck, error) {
+	if client.ResolveNames {
+		instanceIDs = append([]string(nil), instanceIDs...)
+		if err := client.instanceResolver().resolveSlice(instanceIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return client.checkTermination(instanceIDs)
+}
+
+// checkTermination checks instances given by ID against the client's termination policy.
+func (client *Client) checkTermination(instanceIDs []string) (*TerminationCheck, error) {
+	check := &TerminationCheck{}
+
+	policy := client.TerminationPolicy
+	if policy == nil {
+		check.Allowed = append(check.Allowed, instanceIDs...)
+		return check, nil
+	}
+
+	for _, id := range instanceIDs {
+		described, err := client.describeInstance(&DescribeInstanceInput{InstanceID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		i := described.DescribeInstance
+		if reason := policy.protects(id, i); reason != "" {
+			check.Protected = append(check.Protected, ProtectedInstance{
+				ID:     id,
+				Name:   i.VMName,
+				Reason: reason,
+			})
+			continue
+		}
+
+		check.Allowed = append(check.Allowed, id)
+	}
+
+	return check, nil
+}
+
+// refuse returns the error that blocks terminating the protected instances of a check. Protected
+// instances are refused unless forced, and forcing requires an audit log to record the override.
+func (client *Client) refuse(check *TerminationCheck, force bool) error {
+	if len(check.Protected) == 0 {
+		return nil
+	}
+
+	if !force {
+		return &TerminationProtectedError{Instances: check.Protected}
+	}
+
+	if client.AuditLog == nil {
+		return fmt.Errorf("atlantic: termination policy overrides must be recorded, but the client has no AuditLog")
+	}
+
+	return nil
+}
+
+// protects returns the reason an instance is protected, or an empty string if it is not.
+func (policy *TerminationPolicy) protects(id string, i DescribeInstance) string {
+	if isFlagSet(i.DisallowDeletion) {
+		return "instance is flagged disallow_deletion"
+	}
+
+	for _, denied := range policy.DenyList {
+		if denied == id {
+			return "instance is on the deny list"
+		}
+	}
+
+	for _, pattern := range policy.ProtectedNames {
+		if matched, _ := path.Match(pattern, i.VMName); matched {
+			return fmt.Sprintf("instance name matches protected pattern %q", pattern)
+		}
+	}
+
+	return ""
+}
+
+// isFlagSet reports whether an API flag value represents true.
+func isFlagSet(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes", "1", "true":
+		return true
+	}
+	return false
+}
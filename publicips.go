@@ -1,6 +1,7 @@
 package atlantic
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -60,6 +61,8 @@ type ReservePublicIP struct {
 type ReservePublicIPInput struct {
 	Location string
 	Qty      int
+	// OverrideBudget skips the client's budget check.
+	OverrideBudget bool
 }
 
 // ReservePublicIPOutput represents the output from reserving a public IP.
@@ -149,6 +152,15 @@ type UnassignPublicIPOutput struct {
 
 // ListPublicIPs returns the details of the additional public IP addresses reserved on the account.
 func (client *Client) ListPublicIPs(input *ListPublicIPsInput) (*ListPublicIPsOutput, error) {
+	return client.listPublicIPs(context.Background(), input)
+}
+
+// listPublicIPs is ListPublicIPs with a context.
+func (client *Client) listPublicIPs(ctx context.Context, input *ListPublicIPsInput) (*ListPublicIPsOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "list-public-ips")
@@ -163,7 +175,7 @@ func (client *Client) ListPublicIPs(input *ListPublicIPsInput) (*ListPublicIPsOu
 
 	action := actionBuilder.String()
 
-	response, err := client.request(action)
+	response, err := client.requestContext(ctx, action)
 	if err != nil {
 		return nil, err
 	}
@@ -187,10 +199,18 @@ func (client *Client) ListPublicIPs(input *ListPublicIPsInput) (*ListPublicIPsOu
 
 // ReservePublicIP reserves one or more public IP address in specified location.
 func (client *Client) ReservePublicIP(input *ReservePublicIPInput) (*ReservePublicIPOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	if input.Location == "" {
 		return nil, fmt.Errorf("atlantic: Location must be provided")
 	}
 
+	if err := client.checkReservePublicIPBudget(input); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "reserve-public-ip&location=%s", input.Location)
@@ -230,6 +250,10 @@ func (client *Client) ReleasePublicIP(input *ReleasePublicIPInput) (*ReleasePubl
 		return nil, fmt.Errorf("atlantic: IP Address must be provided")
 	}
 
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "release-public-ip")
@@ -269,6 +293,15 @@ func (client *Client) ReleasePublicIP(input *ReleasePublicIPInput) (*ReleasePubl
 
 // AssignPublicIP assigns one or more public IP addresses to a server.
 func (client *Client) AssignPublicIP(input *AssignPublicIPInput) (*AssignPublicIPOutput, error) {
+	return client.assignPublicIP(context.Background(), input)
+}
+
+// assignPublicIP is AssignPublicIP with a context.
+func (client *Client) assignPublicIP(ctx context.Context, input *AssignPublicIPInput) (*AssignPublicIPOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	if input.InstanceID == "" {
 		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
 	}
@@ -277,6 +310,10 @@ func (client *Client) AssignPublicIP(input *AssignPublicIPInput) (*AssignPublicI
 		return nil, fmt.Errorf("atlantic: IP address must be provided")
 	}
 
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "assign-public-ip&instanceid=%s", input.InstanceID)
@@ -292,7 +329,7 @@ func (client *Client) AssignPublicIP(input *AssignPublicIPInput) (*AssignPublicI
 
 	action := actionBuilder.String()
 
-	response, err := client.request(action)
+	response, err := client.requestContext(ctx, action)
 	if err != nil {
 		return nil, err
 	}
@@ -316,10 +353,19 @@ func (client *Client) AssignPublicIP(input *AssignPublicIPInput) (*AssignPublicI
 
 // UnassignPublicIP unassigns one or more public IP addresses from server.
 func (client *Client) UnassignPublicIP(input *UnassignPublicIPInput) (*UnassignPublicIPOutput, error) {
+	return client.unassignPublicIP(context.Background(), input)
+}
+
+// unassignPublicIP is UnassignPublicIP with a context.
+func (client *Client) unassignPublicIP(ctx context.Context, input *UnassignPublicIPInput) (*UnassignPublicIPOutput, error) {
 	if len(input.IPAddress) == 0 {
 		return nil, fmt.Errorf("atlantic: IP address must be provided")
 	}
 
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
 	var actionBuilder strings.Builder
 
 	fmt.Fprintf(&actionBuilder, "unassign-public-ip")
@@ -335,7 +381,7 @@ func (client *Client) UnassignPublicIP(input *UnassignPublicIPInput) (*UnassignP
 
 	action := actionBuilder.String()
 
-	response, err := client.request(action)
+	response, err := client.requestContext(ctx, action)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,339 @@
+package atlantic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AmbiguousNameError is returned when a name matches more than one resource.
+type AmbiguousNameError struct {
+	Kind       string
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("atlantic: %s name %q is ambiguous, candidates: %s", e.Kind, e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// candidate represents a resource that a name or ID can be resolved to.
+type candidate struct {
+	ID   string
+	Name string
+}
+
+// resolveCandidate returns the ID of the candidate whose ID or name matches nameOrID.
+// IDs take precedence over names, and exact names over case-insensitive ones.
+func resolveCandidate(kind string, nameOrID string, candidates []candidate) (string, error) {
+	for _, c := range candidates {
+		if c.ID == nameOrID {
+			return c.ID, nil
+		}
+	}
+
+	var matches []candidate
+	for _, c := range candidates {
+		if c.Name == nameOrID {
+			matches = append(matches, c)
+		}
+	}
+
+	if len(matches) == 0 {
+		for _, c := range candidates {
+			if strings.EqualFold(c.ID, nameOrID) || strings.EqualFold(c.Name, nameOrID) {
+				matches = append(matches, c)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("atlantic: %s %q not found", kind, nameOrID)
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	e := &AmbiguousNameError{Kind: kind, Name: nameOrID}
+	for _, m := range matches {
+		e.Candidates = append(e.Candidates, fmt.Sprintf("%s (%s)", m.ID, m.Name))
+	}
+	return "", e
+}
+
+// resolver resolves names or IDs of a single resource kind, listing the resources at most once.
+type resolver struct {
+	kind       string
+	list       func() ([]candidate, error)
+	candidates []candidate
+	listed     bool
+}
+
+// resolve returns the ID for nameOrID, leaving empty values untouched.
+func (r *resolver) resolve(nameOrID string) (string, error) {
+	if nameOrID == "" {
+		return "", nil
+	}
+
+	if !r.listed {
+		candidates, err := r.list()
+		if err != nil {
+			return "", err
+		}
+		r.candidates = candidates
+		r.listed = true
+	}
+
+	return resolveCandidate(r.kind, nameOrID, r.candidates)
+}
+
+// resolveInto replaces each value in place with its resolved ID.
+func (r *resolver) resolveInto(values ...*string) error {
+	for _, v := range values {
+		id, err := r.resolve(*v)
+		if err != nil {
+			return err
+		}
+		*v = id
+	}
+	return nil
+}
+
+// resolveSlice replaces each element of values with its resolved ID.
+func (r *resolver) resolveSlice(values []string) error {
+	for i := range values {
+		if err := r.resolveInto(&values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *Client) instanceResolver() *resolver {
+	return &resolver{kind: "instance", list: func() ([]candidate, error) {
+		instances, err := client.ListInstances()
+		if err != nil {
+			return nil, err
+		}
+
+		var cc []candidate
+		for _, i := range instances.ListInstances {
+			cc = append(cc, candidate{ID: i.ID, Name: i.Name})
+		}
+		return cc, nil
+	}}
+}
+
+func (client *Client) imageResolver() *resolver {
+	return &resolver{kind: "image", list: func() ([]candidate, error) {
+		images, err := client.DescribeImage(&DescribeImageInput{})
+		if err != nil {
+			return nil, err
+		}
+
+		var cc []candidate
+		for _, i := range images.Images {
+			cc = append(cc, candidate{ID: i.ID, Name: i.DisplayName})
+		}
+		return cc, nil
+	}}
+}
+
+func (client *Client) locationResolver() *resolver {
+	return &resolver{kind: "location", list: func() ([]candidate, error) {
+		locations, err := client.ListLocations()
+		if err != nil {
+			return nil, err
+		}
+
+		var cc []candidate
+		for _, l := range locations.Locations {
+			cc = append(cc, candidate{ID: l.Code, Name: l.Name})
+		}
+		return cc, nil
+	}}
+}
+
+func (client *Client) planResolver() *resolver {
+	return &resolver{kind: "plan", list: func() ([]candidate, error) {
+		plans, err := client.DescribePlan(&DescribePlanInput{})
+		if err != nil {
+			return nil, err
+		}
+
+		var cc []candidate
+		for _, p := range plans.Plans {
+			cc = append(cc, candidate{ID: p.Name, Name: p.Name})
+		}
+		return cc, nil
+	}}
+}
+
+func (client *Client) sshKeyResolver() *resolver {
+	return &resolver{kind: "ssh key", list: func() ([]candidate, error) {
+		keys, err := client.ListSSHKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		var cc []candidate
+		for _, k := range keys.Keys {
+			cc = append(cc, candidate{ID: k.ID, Name: k.Name})
+		}
+		return cc, nil
+	}}
+}
+
+// ResolveInstance returns the ID of the instance with the given ID or vm_name.
+func (client *Client) ResolveInstance(nameOrID string) (string, error) {
+	return client.instanceResolver().resolve(nameOrID)
+}
+
+// ResolveImage returns the ID of the image with the given ID or display name.
+func (client *Client) ResolveImage(nameOrID string) (string, error) {
+	return client.imageResolver().resolve(nameOrID)
+}
+
+// ResolveLocation returns the code of the location with the given code or location name.
+func (client *Client) ResolveLocation(nameOrCode string) (string, error) {
+	return client.locationResolver().resolve(nameOrCode)
+}
+
+// ResolvePlan returns the canonical name of the plan with the given plan name.
+func (client *Client) ResolvePlan(name string) (string, error) {
+	return client.planResolver().resolve(name)
+}
+
+// ResolveSSHKey returns the ID of the SSH key with the given ID or key name.
+func (client *Client) ResolveSSHKey(nameOrID string) (string, error) {
+	return client.sshKeyResolver().resolve(nameOrID)
+}
+
+// nameResolver is implemented by inputs whose ID fields may hold names.
+type nameResolver interface {
+	ResolveNames(client *Client) error
+}
+
+// resolveNames resolves the names in an input to IDs when the client has ResolveNames enabled.
+// Internal callers that already hold IDs use non-resolving helpers such as describeInstance, so
+// that passes over the inventory do not list the account once per instance.
+func (client *Client) resolveNames(input nameResolver) error {
+	if !client.ResolveNames {
+		return nil
+	}
+	return input.ResolveNames(client)
+}
+
+// ResolveNames replaces the image name in the input with its ID.
+func (input *DescribeImageInput) ResolveNames(client *Client) error {
+	return client.imageResolver().resolveInto(&input.ImageID)
+}
+
+// ResolveNames replaces the plan name in the input with its canonical name.
+func (input *DescribePlanInput) ResolveNames(client *Client) error {
+	return client.planResolver().resolveInto(&input.PlanName)
+}
+
+// ResolveNames replaces the image, plan, location, SSH key and clone source names in the input with their IDs.
+func (input *RunInstanceInput) ResolveNames(client *Client) error {
+	if err := client.imageResolver().resolveInto(&input.ImageID); err != nil {
+		return err
+	}
+	if err := client.planResolver().resolveInto(&input.PlanName); err != nil {
+		return err
+	}
+	if err := client.locationResolver().resolveInto(&input.Location); err != nil {
+		return err
+	}
+	if err := client.sshKeyResolver().resolveInto(&input.KeyID); err != nil {
+		return err
+	}
+	return client.instanceResolver().resolveInto(&input.CloneImage)
+}
+
+// ResolveNames replaces the instance names in the input with their IDs.
+func (input *TerminateInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveSlice(input.InstanceID)
+}
+
+// ResolveNames replaces the instance name in the input with its ID.
+func (input *DescribeInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveInto(&input.InstanceID)
+}
+
+// ResolveNames replaces the instance name in the input with its ID.
+func (input *RebootInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveInto(&input.InstanceID)
+}
+
+// ResolveNames replaces the instance names in the input with their IDs.
+func (input *ShutdownInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveSlice(input.InstanceID)
+}
+
+// ResolveNames replaces the instance names in the input with their IDs.
+func (input *PowerOnInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveSlice(input.InstanceID)
+}
+
+// ResolveNames replaces the instance and plan names in the input with their IDs.
+func (input *ResizeInstanceInput) ResolveNames(client *Client) error {
+	if err := client.instanceResolver().resolveInto(&input.InstanceID); err != nil {
+		return err
+	}
+	return client.planResolver().resolveInto(&input.PlanName)
+}
+
+// ResolveNames replaces the instance, plan and image names in the input with their IDs.
+func (input *ReprovisionInstanceInput) ResolveNames(client *Client) error {
+	if err := client.instanceResolver().resolveInto(&input.InstanceID); err != nil {
+		return err
+	}
+	if err := client.planResolver().resolveInto(&input.PlanName); err != nil {
+		return err
+	}
+	return client.imageResolver().resolveInto(&input.ImageID)
+}
+
+// ResolveNames replaces the location name in the input with its code.
+func (input *ListPublicIPsInput) ResolveNames(client *Client) error {
+	return client.locationResolver().resolveInto(&input.Location)
+}
+
+// ResolveNames replaces the location name in the input with its code.
+func (input *ReservePublicIPInput) ResolveNames(client *Client) error {
+	return client.locationResolver().resolveInto(&input.Location)
+}
+
+// ResolveNames replaces the instance name in the input with its ID.
+func (input *AssignPublicIPInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveInto(&input.InstanceID)
+}
+
+// ResolveNames replaces the SSH key names in the input with their IDs.
+func (input *DeleteSSHKeyInput) ResolveNames(client *Client) error {
+	return client.sshKeyResolver().resolveSlice(input.KeyIDs)
+}
+
+// ResolveNames replaces the instance names in the input with their IDs.
+func (input *TeardownInstanceInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveSlice(input.InstanceID)
+}
+
+// ResolveNames replaces the source instance, plan, location and SSH key names in the input with their IDs.
+func (input *CloneInstanceInput) ResolveNames(client *Client) error {
+	if err := client.instanceResolver().resolveInto(&input.SourceInstanceID); err != nil {
+		return err
+	}
+	if err := client.planResolver().resolveInto(&input.PlanName); err != nil {
+		return err
+	}
+	if err := client.locationResolver().resolveInto(&input.Location); err != nil {
+		return err
+	}
+	return client.sshKeyResolver().resolveInto(&input.KeyID)
+}
+
+// ResolveNames replaces the instance name in the input with its ID.
+func (input *MoveFloatingIPInput) ResolveNames(client *Client) error {
+	return client.instanceResolver().resolveInto(&input.InstanceID)
+}
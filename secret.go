@@ -0,0 +1,53 @@
+package atlantic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// redacted is printed in place of a secret value.
+const redacted = "[REDACTED]"
+
+// Secret represents a sensitive value, such as a password or private key, that is
+// redacted whenever it is printed, formatted or marshaled to JSON.
+type Secret string
+
+// Reveal returns the underlying secret value.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns a redacted representation of the secret.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString returns a redacted representation of the secret for the %#v verb.
+func (s Secret) GoString() string {
+	return fmt.Sprintf("atlantic.Secret(%q)", s.String())
+}
+
+// Format implements fmt.Formatter so that every verb, including %s, %q, %x and %v,
+// prints the redacted representation.
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, s.GoString())
+			return
+		}
+		fmt.Fprint(f, s.String())
+	case 'q':
+		fmt.Fprintf(f, "%q", s.String())
+	default:
+		fmt.Fprint(f, s.String())
+	}
+}
+
+// MarshalJSON returns a redacted JSON representation of the secret.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
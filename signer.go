@@ -0,0 +1,189 @@
+package atlantic
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Signer signs requests to Atlantic's API given the request's Unix timestamp and random GUID.
+// Signing is aborted when ctx is done.
+type Signer interface {
+	Sign(ctx context.Context, timestamp int64, rndguid string) (string, error)
+}
+
+// HMACSigner signs requests with HMAC-SHA256 keyed by the account's private key. It is the signer
+// used by clients that have no Signer set.
+type HMACSigner struct {
+	PrivateKey Secret
+}
+
+// Sign returns the base64-encoded HMAC-SHA256 of the timestamp followed by the random GUID.
+func (signer HMACSigner) Sign(ctx context.Context, timestamp int64, rndguid string) (string, error) {
+	return signHMAC(signer.PrivateKey, timestamp, rndguid), nil
+}
+
+// signHMAC returns the signature of a request for the given private key.
+func signHMAC(privateKey Secret, timestamp int64, rndguid string) string {
+	stringToSign := fmt.Sprintf("%d%s", timestamp, rndguid)
+
+	m := hmac.New(sha256.New, []byte(privateKey.Reveal()))
+	m.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(m.Sum(nil))
+}
+
+// signer returns the client's signer, defaulting to an HMACSigner over its private key.
+func (client *Client) signer() Signer {
+	if client.Signer != nil {
+		return client.Signer
+	}
+	return HMACSigner{PrivateKey: client.PrivateKey}
+}
+
+// signRequest represents a request to a signer daemon.
+type signRequest struct {
+	Timestamp int64  `json:"timestamp"`
+	Rndguid   string `json:"rndguid"`
+}
+
+// signResponse represents a response from a signer daemon.
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// RemoteSigner delegates signing to a signer daemon, so that the private key never needs to be
+// loaded into the application. The daemon is reached over HTTP, or over a Unix socket when
+// SocketPath is set, and can be served with NewSignerHandler.
+type RemoteSigner struct {
+	// URL is the daemon's signing endpoint. With a Unix socket, only its path is significant.
+	URL string
+	// SocketPath is the path of the daemon's Unix socket. When it is set, requests are sent over
+	// the socket whatever the transport of HTTPClient.
+	SocketPath string
+	// HTTPClient defaults to a client with a ten second timeout.
+	HTTPClient *http.Client
+}
+
+// NewRemoteSigner returns a signer delegating to the daemon at url.
+func NewRemoteSigner(url string) *RemoteSigner {
+	return &RemoteSigner{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewUnixSigner returns a signer delegating to the daemon listening on the Unix socket at socketPath.
+func NewUnixSigner(socketPath string) *RemoteSigner {
+	return &RemoteSigner{
+		URL:        "http://unix/sign",
+		SocketPath: socketPath,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// httpClient returns the client used to reach the daemon, dialing the Unix socket when one is set.
+func (signer *RemoteSigner) httpClient() *http.Client {
+	httpClient := signer.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if signer.SocketPath == "" {
+		return httpClient
+	}
+
+	socketPath := signer.SocketPath
+	unixClient := *httpClient
+	unixClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+		// The transport is built per request, so connections are not kept for reuse.
+		DisableKeepAlives: true,
+	}
+
+	return &unixClient
+}
+
+// Sign asks the daemon for the signature of a request.
+func (signer *RemoteSigner) Sign(ctx context.Context, timestamp int64, rndguid string) (string, error) {
+	body, err := json.Marshal(signRequest{Timestamp: timestamp, Rndguid: rndguid})
+	if err != nil {
+		return "", err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", signer.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := signer.httpClient().Do(request)
+	if err != nil {
+		return "", fmt.Errorf("atlantic: signer unavailable: %v", err)
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("atlantic: signer returned %s: %s", response.Status, bytes.TrimSpace(data))
+	}
+
+	var res signResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return "", err
+	}
+
+	if res.Signature == "" {
+		return "", fmt.Errorf("atlantic: signer returned an empty signature")
+	}
+
+	return res.Signature, nil
+}
+
+// NewSignerHandler returns the handler a signer daemon serves for RemoteSigner clients. It refuses
+// timestamps more than five minutes from the daemon's clock, so that signatures cannot be minted
+// in advance for later use.
+func NewSignerHandler(signer Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Rndguid == "" || req.Timestamp <= 0 {
+			http.Error(w, "invalid sign request", http.StatusBadRequest)
+			return
+		}
+
+		signedAt := time.Unix(req.Timestamp, 0)
+		if now := time.Now(); signedAt.Before(now.Add(-defaultMaxSkew)) || signedAt.After(now.Add(defaultMaxSkew)) {
+			http.Error(w, "timestamp outside the allowed window", http.StatusBadRequest)
+			return
+		}
+
+		signature, err := signer.Sign(r.Context(), req.Timestamp, req.Rndguid)
+		if err != nil {
+			http.Error(w, "signing failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(signResponse{Signature: signature})
+	})
+}
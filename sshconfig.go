@@ -0,0 +1,208 @@
+package atlantic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sshConfigBeginMarker = "# BEGIN go-atlantic managed block"
+	sshConfigEndMarker   = "# END go-atlantic managed block"
+)
+
+// SSHIdentity maps instances whose name matches a path.Match pattern to an identity file.
+type SSHIdentity struct {
+	Pattern string
+	File    string
+}
+
+// SSHConfigOptions represents the options for generating an OpenSSH client config.
+type SSHConfigOptions struct {
+	// Identities are checked in order, and the first matching pattern sets IdentityFile.
+	Identities []SSHIdentity
+	// DefaultIdentityFile is used for instances that match no identity pattern.
+	DefaultIdentityFile string
+	// Includes are emitted as Include directives ahead of the Host blocks.
+	Includes []string
+	// PreferIPv6 uses the IPv6 address as HostName when the instance has one.
+	PreferIPv6 bool
+	// User overrides vm_username for every host.
+	User string
+}
+
+// SSHConfig returns an OpenSSH client config with a Host block for every active instance.
+func (client *Client) SSHConfig(options *SSHConfigOptions) (string, error) {
+	instances, err := client.describeInstances()
+	if err != nil {
+		return "", err
+	}
+
+	return GenerateSSHConfig(instances, options), nil
+}
+
+// GenerateSSHConfig returns an OpenSSH client config with a Host block for each instance, using
+// vm_name as the alias, vm_ip_address (or the IPv6 address) as HostName and vm_username as User.
+// Aliases have whitespace and pattern characters replaced, and are suffixed with the instance ID
+// when taken. Instances without an address are skipped.
+func GenerateSSHConfig(instances []DescribeInstance, options *SSHConfigOptions) string {
+	if options == nil {
+		options = &SSHConfigOptions{}
+	}
+
+	var b strings.Builder
+
+	for _, include := range options.Includes {
+		fmt.Fprintf(&b, "Include %s\n", include)
+	}
+
+	aliases := map[string]bool{}
+
+	for _, i := range instances {
+		hostName := i.VMIPAddress
+		if (options.PreferIPv6 || hostName == "") && i.VMIPv6Address != "" {
+			hostName = i.VMIPv6Address
+		}
+
+		alias := sshHostAlias(i.VMName)
+		if alias == "" || hostName == "" {
+			continue
+		}
+		if aliases[alias] {
+			alias += "-" + i.ID
+		}
+		aliases[alias] = true
+
+		if b.Len() > 0 {
+			fmt.Fprintf(&b, "\n")
+		}
+
+		fmt.Fprintf(&b, "Host %s\n", alias)
+		fmt.Fprintf(&b, "  HostName %s\n", hostName)
+
+		user := options.User
+		if user == "" {
+			user = i.VMUsername
+		}
+		if user != "" {
+			fmt.Fprintf(&b, "  User %s\n", user)
+		}
+
+		if identity := options.identityFile(i.VMName); identity != "" {
+			fmt.Fprintf(&b, "  IdentityFile %s\n", identity)
+		}
+	}
+
+	return b.String()
+}
+
+// sshHostAlias returns a Host alias for an instance name. Whitespace becomes '-' and the pattern
+// characters '*', '?', '!' and ',' become '_', so that the alias only ever matches itself.
+func sshHostAlias(name string) string {
+	alias := strings.Join(strings.Fields(name), "-")
+	return strings.NewReplacer("*", "_", "?", "_", "!", "_", ",", "_").Replace(alias)
+}
+
+// identityFile returns the identity file for an instance name.
+func (options *SSHConfigOptions) identityFile(name string) string {
+	for _, identity := range options.Identities {
+		if matched, _ := path.Match(identity.Pattern, name); matched {
+			return identity.File
+		}
+	}
+	return options.DefaultIdentityFile
+}
+
+// ReplaceSSHConfigBlock returns config with its managed block replaced by block, or with the
+// managed block appended when config has none. Content outside the markers is left untouched.
+func ReplaceSSHConfigBlock(config string, block string) (string, error) {
+	// Match all closes any open Host block, so that the managed block does not inherit the
+	// scope of the content before it, and the content after it does not inherit its scope.
+	var managed strings.Builder
+	fmt.Fprintf(&managed, "%s\nMatch all\n", sshConfigBeginMarker)
+	if block != "" {
+		fmt.Fprintf(&managed, "%s\n", strings.TrimRight(block, "\n"))
+	}
+	fmt.Fprintf(&managed, "Match all\n%s\n", sshConfigEndMarker)
+
+	begin := strings.Index(config, sshConfigBeginMarker)
+	end := strings.Index(config, sshConfigEndMarker)
+
+	if begin < 0 && end < 0 {
+		if config != "" && !strings.HasSuffix(config, "\n") {
+			config += "\n"
+		}
+		if config != "" {
+			config += "\n"
+		}
+		return config + managed.String(), nil
+	}
+
+	if begin < 0 || end < begin {
+		return "", fmt.Errorf("atlantic: malformed managed block in ssh config")
+	}
+
+	end += len(sshConfigEndMarker)
+	if end < len(config) && config[end] == '\n' {
+		end++
+	}
+
+	return config[:begin] + managed.String() + config[end:], nil
+}
+
+// UpdateSSHConfigFile rewrites the managed block of the OpenSSH client config at filename,
+// creating the file if it does not exist.
+func UpdateSSHConfigFile(filename string, block string) error {
+	filename, err := resolveSymlink(filename)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	config, err := ReplaceSSHConfigBlock(string(existing), block)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, []byte(config), 0600)
+}
+
+// maxSymlinkHops bounds the number of symlinks followed when resolving a path.
+const maxSymlinkHops = 40
+
+// resolveSymlink follows filename through any symlinks, including a dangling last one, so that
+// the config is written through the link rather than replacing it with a regular file.
+func resolveSymlink(filename string) (string, error) {
+	for hops := 0; hops < maxSymlinkHops; hops++ {
+		info, err := os.Lstat(filename)
+		if os.IsNotExist(err) {
+			return filename, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			return filename, nil
+		}
+
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return "", err
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(filename), target)
+		}
+		filename = target
+	}
+
+	return "", fmt.Errorf("atlantic: too many levels of symbolic links: %s", filename)
+}
@@ -0,0 +1,252 @@
+package atlantic
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// MinRSAKeyBits is the smallest RSA key size accepted.
+const MinRSAKeyBits = 2048
+
+// SSHPublicKey represents a parsed OpenSSH public key.
+type SSHPublicKey struct {
+	Type    string
+	Blob    []byte
+	Comment string
+	Bits    int
+}
+
+// DuplicateSSHKeyError is returned when adding a key that is already on the account.
+type DuplicateSSHKeyError struct {
+	ID          string
+	Name        string
+	Fingerprint string
+}
+
+func (e *DuplicateSSHKeyError) Error() string {
+	return fmt.Sprintf("atlantic: ssh key %s is already on the account as %s (%s)", e.Fingerprint, e.ID, e.Name)
+}
+
+// sshCurves maps ECDSA key types to their curves.
+var sshCurves = map[string]struct {
+	name  string
+	curve func() elliptic.Curve
+}{
+	"ecdsa-sha2-nistp256": {"nistp256", elliptic.P256},
+	"ecdsa-sha2-nistp384": {"nistp384", elliptic.P384},
+	"ecdsa-sha2-nistp521": {"nistp521", elliptic.P521},
+}
+
+// isSSHKeyType reports whether t is a supported key type.
+func isSSHKeyType(t string) bool {
+	_, ecdsa := sshCurves[t]
+	return t == "ssh-ed25519" || t == "ssh-rsa" || ecdsa
+}
+
+// ParseAuthorizedKey parses a public key in OpenSSH authorized_keys format, optionally preceded by
+// key options. ssh-ed25519, ssh-rsa of at least MinRSAKeyBits and ecdsa-sha2-* keys are accepted.
+func ParseAuthorizedKey(line string) (*SSHPublicKey, error) {
+	fields := splitAuthorizedKey(strings.TrimSpace(line))
+
+	start := -1
+	for i, f := range fields {
+		if isSSHKeyType(f) {
+			start = i
+			break
+		}
+	}
+
+	if start < 0 {
+		if len(fields) > 0 && strings.HasPrefix(fields[0], "ssh-dss") {
+			return nil, fmt.Errorf("atlantic: ssh-dss keys are not accepted")
+		}
+		return nil, fmt.Errorf("atlantic: no supported ssh key type found")
+	}
+
+	if start+1 >= len(fields) {
+		return nil, fmt.Errorf("atlantic: ssh key data missing")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[start+1])
+	if err != nil {
+		return nil, fmt.Errorf("atlantic: invalid ssh key encoding: %v", err)
+	}
+
+	key := &SSHPublicKey{
+		Type:    fields[start],
+		Blob:    blob,
+		Comment: strings.Join(fields[start+2:], " "),
+	}
+
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// splitAuthorizedKey splits a line on whitespace, keeping quoted option values together.
+func splitAuthorizedKey(line string) []string {
+	var fields []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// validate checks the key blob against its type and rejects weak keys.
+func (key *SSHPublicKey) validate() error {
+	r := bytes.NewReader(key.Blob)
+
+	blobType, err := readSSHString(r)
+	if err != nil {
+		return err
+	}
+
+	if string(blobType) != key.Type {
+		return fmt.Errorf("atlantic: ssh key type %s does not match encoded type %s", key.Type, blobType)
+	}
+
+	switch key.Type {
+	case "ssh-ed25519":
+		pub, err := readSSHString(r)
+		if err != nil {
+			return err
+		}
+		if len(pub) != 32 {
+			return fmt.Errorf("atlantic: invalid ed25519 key length %d", len(pub))
+		}
+		key.Bits = 256
+
+	case "ssh-rsa":
+		e, err := readSSHString(r)
+		if err != nil {
+			return err
+		}
+		n, err := readSSHString(r)
+		if err != nil {
+			return err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if exponent.Cmp(big.NewInt(3)) < 0 || exponent.Bit(0) == 0 {
+			return fmt.Errorf("atlantic: invalid rsa public exponent")
+		}
+
+		key.Bits = new(big.Int).SetBytes(n).BitLen()
+		if key.Bits < MinRSAKeyBits {
+			return fmt.Errorf("atlantic: rsa key of %d bits is weaker than the minimum of %d", key.Bits, MinRSAKeyBits)
+		}
+
+	default:
+		curve := sshCurves[key.Type]
+
+		name, err := readSSHString(r)
+		if err != nil {
+			return err
+		}
+		if string(name) != curve.name {
+			return fmt.Errorf("atlantic: ecdsa curve %s does not match key type %s", name, key.Type)
+		}
+
+		point, err := readSSHString(r)
+		if err != nil {
+			return err
+		}
+		if x, _ := elliptic.Unmarshal(curve.curve(), point); x == nil {
+			return fmt.Errorf("atlantic: invalid ecdsa public key point")
+		}
+		key.Bits = curve.curve().Params().BitSize
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("atlantic: unexpected trailing data in ssh key")
+	}
+
+	return nil
+}
+
+// readSSHString reads a length-prefixed string from an SSH wire-format blob.
+func readSSHString(r *bytes.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("atlantic: truncated ssh key")
+	}
+
+	if int64(length) > int64(r.Len()) {
+		return nil, fmt.Errorf("atlantic: truncated ssh key")
+	}
+
+	b := make([]byte, length)
+	r.Read(b)
+
+	return b, nil
+}
+
+// FingerprintSHA256 returns the key's fingerprint in the "SHA256:..." format used by OpenSSH.
+func (key *SSHPublicKey) FingerprintSHA256() string {
+	sum := sha256.Sum256(key.Blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// FingerprintMD5 returns the key's fingerprint in the legacy "MD5:aa:bb:..." format.
+func (key *SSHPublicKey) FingerprintMD5() string {
+	sum := md5.Sum(key.Blob)
+
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return "MD5:" + strings.Join(hex, ":")
+}
+
+// String returns the key in authorized_keys format, without options.
+func (key *SSHPublicKey) String() string {
+	s := key.Type + " " + base64.StdEncoding.EncodeToString(key.Blob)
+	if key.Comment != "" {
+		s += " " + key.Comment
+	}
+	return s
+}
+
+// matchesFingerprint reports whether fingerprint identifies the key, in either SHA256 or MD5
+// format, with or without its prefix.
+func (key *SSHPublicKey) matchesFingerprint(fingerprint string) bool {
+	fingerprint = strings.TrimSpace(fingerprint)
+
+	sha := key.FingerprintSHA256()
+	if fingerprint == sha || fingerprint == strings.TrimPrefix(sha, "SHA256:") {
+		return true
+	}
+
+	md := key.FingerprintMD5()
+	fingerprint = strings.ToLower(fingerprint)
+	return fingerprint == strings.ToLower(md) || fingerprint == strings.TrimPrefix(md, "MD5:")
+}
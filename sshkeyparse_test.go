@@ -0,0 +1,169 @@
+package atlantic
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const testEd25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMcwVXDVHH8jdhaonlfrl5WeEMo0S0ofkv5BJ89zdmyu alice@example"
+
+// sshBlob encodes values as SSH wire-format strings.
+func sshBlob(values ...[]byte) []byte {
+	var b bytes.Buffer
+	for _, v := range values {
+		binary.Write(&b, binary.BigEndian, uint32(len(v)))
+		b.Write(v)
+	}
+	return b.Bytes()
+}
+
+func rsaTestKey(t *testing.T, bits int) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := sshBlob([]byte("ssh-rsa"), big.NewInt(int64(key.E)).Bytes(), key.N.Bytes())
+	return "ssh-rsa " + base64.StdEncoding.EncodeToString(blob)
+}
+
+func ecdsaTestKey(t *testing.T, keyType string, curveName string, curve elliptic.Curve) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := elliptic.Marshal(curve, key.X, key.Y)
+	blob := sshBlob([]byte(keyType), []byte(curveName), point)
+	return keyType + " " + base64.StdEncoding.EncodeToString(blob)
+}
+
+func TestParseAuthorizedKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		comment string
+	}{
+		{"plain", testEd25519Key, "alice@example"},
+		{"surrounding whitespace", "  " + testEd25519Key + "\n", "alice@example"},
+		{"options", `no-pty,from="10.0.0.0/8" ` + testEd25519Key, "alice@example"},
+		{"quoted option with spaces", `command="echo hello world",no-pty ` + testEd25519Key, "alice@example"},
+		{"comment with spaces", testEd25519Key + " laptop key", "alice@example laptop key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseAuthorizedKey(tt.line)
+			if err != nil {
+				t.Fatalf("ParseAuthorizedKey() error = %v", err)
+			}
+			if key.Type != "ssh-ed25519" || key.Bits != 256 {
+				t.Errorf("key = %s/%d, want ssh-ed25519/256", key.Type, key.Bits)
+			}
+			if key.Comment != tt.comment {
+				t.Errorf("Comment = %q, want %q", key.Comment, tt.comment)
+			}
+			if strings.Contains(key.String(), "no-pty") || strings.Contains(key.String(), "command=") {
+				t.Errorf("String() = %q, want no options", key.String())
+			}
+		})
+	}
+}
+
+func TestParseAuthorizedKeyFingerprints(t *testing.T) {
+	key, err := ParseAuthorizedKey(testEd25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Expected values are from ssh-keygen -l.
+	if got, want := key.FingerprintSHA256(), "SHA256:q43dCiTXn6INfCqHkLXFx15p6MIsRJiUod3Qq2SXKdI"; got != want {
+		t.Errorf("FingerprintSHA256() = %q, want %q", got, want)
+	}
+	if got, want := key.FingerprintMD5(), "MD5:de:e5:47:c6:3d:0b:86:a4:41:64:28:38:f5:05:5c:8a"; got != want {
+		t.Errorf("FingerprintMD5() = %q, want %q", got, want)
+	}
+}
+
+func TestParseAuthorizedKeyAccepts(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		bits int
+	}{
+		{"rsa 2048", rsaTestKey(t, 2048), 2048},
+		{"ecdsa p256", ecdsaTestKey(t, "ecdsa-sha2-nistp256", "nistp256", elliptic.P256()), 256},
+		{"ecdsa p384", ecdsaTestKey(t, "ecdsa-sha2-nistp384", "nistp384", elliptic.P384()), 384},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseAuthorizedKey(tt.line)
+			if err != nil {
+				t.Fatalf("ParseAuthorizedKey() error = %v", err)
+			}
+			if key.Bits != tt.bits {
+				t.Errorf("Bits = %d, want %d", key.Bits, tt.bits)
+			}
+		})
+	}
+}
+
+func TestParseAuthorizedKeyRejects(t *testing.T) {
+	ed25519Data := strings.Fields(testEd25519Key)[1]
+	p256 := ecdsaTestKey(t, "ecdsa-sha2-nistp256", "nistp256", elliptic.P256())
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"empty", "", "no supported ssh key type"},
+		{"dsa", "ssh-dss AAAAB3NzaC1kc3MAAACBAP", "ssh-dss keys are not accepted"},
+		{"missing data", "ssh-ed25519", "ssh key data missing"},
+		{"bad base64", "ssh-ed25519 !!!", "invalid ssh key encoding"},
+		{"rsa 1024", rsaTestKey(t, 1024), "weaker than the minimum"},
+		{"type mismatch", "ssh-rsa " + ed25519Data, "does not match encoded type"},
+		{"truncated", "ssh-ed25519 " + ed25519Data[:20], "truncated ssh key"},
+		{"ecdsa curve mismatch", ecdsaTestKey(t, "ecdsa-sha2-nistp384", "nistp256", elliptic.P256()), "does not match key type"},
+		{"ecdsa point for another curve", "ecdsa-sha2-nistp384 " + base64.StdEncoding.EncodeToString(
+			sshBlob([]byte("ecdsa-sha2-nistp384"), []byte("nistp384"), ecdsaPoint(t, p256))), "invalid ecdsa public key point"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAuthorizedKey(tt.line)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseAuthorizedKey() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// ecdsaPoint returns the encoded public point of an ecdsa authorized key line.
+func ecdsaPoint(t *testing.T, line string) []byte {
+	t.Helper()
+	blob, err := base64.StdEncoding.DecodeString(strings.Fields(line)[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(blob)
+	for i := 0; i < 2; i++ {
+		if _, err := readSSHString(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	point, err := readSSHString(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return point
+}
@@ -20,6 +20,9 @@ type SSHKey struct {
 	ID        string `json:"key_id"`
 	Name      string `json:"key_name"`
 	PublicKey string `json:"public_key"`
+	// FingerprintSHA256 and FingerprintMD5 are computed locally, and are empty when the public key cannot be parsed.
+	FingerprintSHA256 string `json:"fingerprint_sha256,omitempty"`
+	FingerprintMD5    string `json:"fingerprint_md5,omitempty"`
 }
 
 // ListSSHKeysOutput represents the output from listing SSH keys.
@@ -97,6 +100,10 @@ func (client *Client) ListSSHKeys() (*ListSSHKeysOutput, error) {
 
 	kk := []SSHKey{}
 	for _, k := range res.Response.SSHKeys {
+		if key, err := ParseAuthorizedKey(k.PublicKey); err == nil {
+			k.FingerprintSHA256 = key.FingerprintSHA256()
+			k.FingerprintMD5 = key.FingerprintMD5()
+		}
 		kk = append(kk, k)
 	}
 
@@ -124,6 +131,26 @@ func (client *Client) GetSSHKeyID(keyName string) (string, error) {
 	return "", fmt.Errorf("atlantic: ssh key not found")
 }
 
+// GetSSHKeyByFingerprint returns the SSH key with the given SHA256 or MD5 fingerprint.
+func (client *Client) GetSSHKeyByFingerprint(fingerprint string) (*SSHKey, error) {
+	sshKeys, err := client.ListSSHKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range sshKeys.Keys {
+		key, err := ParseAuthorizedKey(k.PublicKey)
+		if err != nil {
+			continue
+		}
+		if key.matchesFingerprint(fingerprint) {
+			return &k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("atlantic: ssh key not found")
+}
+
 // AddSSHKey adds an SSH key to the account.
 func (client *Client) AddSSHKey(input *AddSSHKeyInput) (*AddSSHKeyOutput, error) {
 	if input.KeyName == "" {
@@ -134,7 +161,24 @@ func (client *Client) AddSSHKey(input *AddSSHKeyInput) (*AddSSHKeyOutput, error)
 		return nil, fmt.Errorf("atlantic: Public key must be provided")
 	}
 
-	action := fmt.Sprintf("add-sshkey&key_name=%s&public_key=%s", input.KeyName, input.PublicKey)
+	key, err := ParseAuthorizedKey(input.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sshKeys, err := client.ListSSHKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range sshKeys.Keys {
+		if k.FingerprintSHA256 == key.FingerprintSHA256() {
+			return nil, &DuplicateSSHKeyError{ID: k.ID, Name: k.Name, Fingerprint: k.FingerprintSHA256}
+		}
+	}
+
+	// The key is sent normalised, so that authorized_keys options are never stored on the account.
+	action := fmt.Sprintf("add-sshkey&key_name=%s&public_key=%s", input.KeyName, key.String())
 
 	response, err := client.request(action)
 	if err != nil {
@@ -156,6 +200,10 @@ func (client *Client) AddSSHKey(input *AddSSHKeyInput) (*AddSSHKeyOutput, error)
 
 // DeleteSSHKey deletes one or more SSH keys from the account.
 func (client *Client) DeleteSSHKey(input *DeleteSSHKeyInput) (*DeleteSSHKeyOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
 	if len(input.KeyIDs) == 0 {
 		return nil, fmt.Errorf("atlantic: SSH key ID must be provided")
 	}
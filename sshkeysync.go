@@ -0,0 +1,272 @@
+package atlantic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Actions in an SSH key sync plan.
+const (
+	SSHKeySyncAdd     = "add"
+	SSHKeySyncDelete  = "delete"
+	SSHKeySyncReplace = "replace"
+)
+
+// DesiredSSHKey represents a key that should be on the account.
+type DesiredSSHKey struct {
+	Name      string
+	PublicKey string
+}
+
+// SSHKeySyncOptions represents the options for planning an SSH key sync.
+type SSHKeySyncOptions struct {
+	// Prune deletes the keys on the account that are not in the desired set.
+	Prune bool
+}
+
+// SSHKeyChange represents a single change in an SSH key sync plan. Replacements add the desired
+// key and then delete the existing key with the same name, whose ID is KeyID.
+type SSHKeyChange struct {
+	Action      string
+	Name        string
+	KeyID       string
+	Fingerprint string
+	PublicKey   string
+}
+
+// SSHKeySyncPlan represents the changes needed to bring the account's keys in line with a desired set.
+type SSHKeySyncPlan struct {
+	Changes   []SSHKeyChange
+	Unchanged []SSHKey
+}
+
+// LoadAuthorizedKeysFile reads desired keys from an authorized_keys file, naming each key after
+// its comment. Blank lines and comment lines are skipped.
+func LoadAuthorizedKeysFile(filename string) ([]DesiredSSHKey, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []DesiredSSHKey
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, err := ParseAuthorizedKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", filename, n, err)
+		}
+
+		if key.Comment == "" {
+			return nil, fmt.Errorf("%s:%d: atlantic: ssh key has no comment to use as its name", filename, n)
+		}
+
+		keys = append(keys, DesiredSSHKey{Name: key.Comment, PublicKey: key.String()})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// LoadPublicKeyDir reads desired keys from the .pub files in a directory, naming each key after
+// its file name without the extension.
+func LoadPublicKeyDir(dir string) ([]DesiredSSHKey, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.pub"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	var keys []DesiredSSHKey
+	for _, filename := range filenames {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParseAuthorizedKey(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+
+		keys = append(keys, DesiredSSHKey{
+			Name:      strings.TrimSuffix(filepath.Base(filename), ".pub"),
+			PublicKey: key.String(),
+		})
+	}
+
+	return keys, nil
+}
+
+// PlanSSHKeySync compares the desired keys with the keys on the account by fingerprint and name.
+// Keys whose fingerprint is already on the account are left unchanged, keys whose name is on the
+// account with another fingerprint are replaced and the remaining keys are added.
+func (client *Client) PlanSSHKeySync(desired []DesiredSSHKey, options *SSHKeySyncOptions) (*SSHKeySyncPlan, error) {
+	if options == nil {
+		options = &SSHKeySyncOptions{}
+	}
+
+	sshKeys, err := client.ListSSHKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	byFingerprint := map[string]SSHKey{}
+	byName := map[string]SSHKey{}
+	for _, k := range sshKeys.Keys {
+		if k.FingerprintSHA256 != "" {
+			byFingerprint[k.FingerprintSHA256] = k
+		}
+		byName[k.Name] = k
+	}
+
+	plan := &SSHKeySyncPlan{}
+	kept := map[string]bool{}
+	wanted := map[string]bool{}
+
+	for _, d := range desired {
+		key, err := ParseAuthorizedKey(d.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("atlantic: invalid key %q: %v", d.Name, err)
+		}
+
+		fingerprint := key.FingerprintSHA256()
+		if wanted[fingerprint] {
+			continue
+		}
+		wanted[fingerprint] = true
+
+		if existing, ok := byFingerprint[fingerprint]; ok {
+			plan.Unchanged = append(plan.Unchanged, existing)
+			kept[existing.ID] = true
+			continue
+		}
+
+		change := SSHKeyChange{
+			Action:      SSHKeySyncAdd,
+			Name:        d.Name,
+			Fingerprint: fingerprint,
+			PublicKey:   d.PublicKey,
+		}
+
+		if existing, ok := byName[d.Name]; ok && !kept[existing.ID] {
+			change.Action = SSHKeySyncReplace
+			change.KeyID = existing.ID
+			kept[existing.ID] = true
+		}
+
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if options.Prune {
+		for _, k := range sshKeys.Keys {
+			if kept[k.ID] {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, SSHKeyChange{
+				Action:      SSHKeySyncDelete,
+				Name:        k.Name,
+				KeyID:       k.ID,
+				Fingerprint: k.FingerprintSHA256,
+			})
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(a, b int) bool {
+		return plan.Changes[a].Name < plan.Changes[b].Name
+	})
+
+	return plan, nil
+}
+
+// WriteTo writes a preview of the plan, one change per line.
+func (plan *SSHKeySyncPlan) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	symbols := map[string]string{
+		SSHKeySyncAdd:     "+",
+		SSHKeySyncDelete:  "-",
+		SSHKeySyncReplace: "~",
+	}
+
+	for _, c := range plan.Changes {
+		fmt.Fprintf(&b, "%s %s %s %s", symbols[c.Action], c.Action, c.Name, c.Fingerprint)
+		if c.KeyID != "" {
+			fmt.Fprintf(&b, " (key %s)", c.KeyID)
+		}
+		fmt.Fprintln(&b)
+	}
+
+	fmt.Fprintf(&b, "%d to change, %d unchanged\n", len(plan.Changes), len(plan.Unchanged))
+
+	return b.WriteTo(w)
+}
+
+// ApplySSHKeySync applies a plan, adding keys before deleting the keys they replace, so that a
+// failed addition never leaves the account without a key. It stops at the first failure. The
+// returned changes are the additions and deletions applied so far, in which a replacement appears
+// as an add followed by a delete.
+func (client *Client) ApplySSHKeySync(plan *SSHKeySyncPlan) ([]SSHKeyChange, error) {
+	applied := []SSHKeyChange{}
+
+	for _, c := range plan.Changes {
+		if c.Action == SSHKeySyncDelete {
+			continue
+		}
+
+		added, err := client.AddSSHKey(&AddSSHKeyInput{KeyName: c.Name, PublicKey: c.PublicKey})
+		if err != nil {
+			return applied, err
+		}
+
+		applied = append(applied, SSHKeyChange{
+			Action:      SSHKeySyncAdd,
+			Name:        c.Name,
+			KeyID:       added.ID,
+			Fingerprint: c.Fingerprint,
+			PublicKey:   c.PublicKey,
+		})
+		if err := client.audit(AuditRecord{Action: "add-sshkey", Target: added.ID, Message: c.Name}); err != nil {
+			return applied, err
+		}
+	}
+
+	for _, c := range plan.Changes {
+		if c.Action == SSHKeySyncAdd {
+			continue
+		}
+
+		if _, err := client.DeleteSSHKey(&DeleteSSHKeyInput{KeyIDs: []string{c.KeyID}}); err != nil {
+			return applied, err
+		}
+
+		deleted := SSHKeyChange{Action: SSHKeySyncDelete, Name: c.Name, KeyID: c.KeyID}
+		if c.Action == SSHKeySyncDelete {
+			deleted.Fingerprint = c.Fingerprint
+		}
+
+		applied = append(applied, deleted)
+		if err := client.audit(AuditRecord{Action: "delete-sshkey", Target: c.KeyID, Message: c.Name}); err != nil {
+			return applied, err
+		}
+	}
+
+	return applied, nil
+}
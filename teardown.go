@@ -0,0 +1,129 @@
+package atlantic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TeardownInstanceInput represents the input for tearing down instances.
+type TeardownInstanceInput struct {
+	InstanceID []string
+	// ReleasePublicIPs releases the additional public IPs after unassigning them,
+	// instead of keeping them reserved on the account.
+	ReleasePublicIPs bool
+	// Force overrides the client's termination policy. It requires the client to have an AuditLog.
+	Force bool
+}
+
+// TeardownStep represents a step taken while tearing down instances.
+type TeardownStep struct {
+	Action     string
+	InstanceID string
+	IPAddress  []string
+	Message    string
+}
+
+// TeardownInstanceOutput represents the output from tearing down instances.
+type TeardownInstanceOutput struct {
+	Steps              []TeardownStep
+	TerminateInstances []TerminateInstance
+}
+
+// TeardownInstance unassigns, and optionally releases, the additional public IPs attached to
+// one or more instances and then terminates them. The steps completed so far are returned
+// alongside any error.
+func (client *Client) TeardownInstance(input *TeardownInstanceInput) (*TeardownInstanceOutput, error) {
+	if err := client.resolveNames(input); err != nil {
+		return nil, err
+	}
+
+	if len(input.InstanceID) == 0 {
+		return nil, fmt.Errorf("atlantic: Instance ID must be provided")
+	}
+
+	output := &TeardownInstanceOutput{}
+
+	check, err := client.checkTermination(input.InstanceID)
+	if err != nil {
+		return output, err
+	}
+	if err := client.refuse(check, input.Force); err != nil {
+		return output, err
+	}
+
+	publicIPs, err := client.ListPublicIPs(&ListPublicIPsInput{})
+	if err != nil {
+		return output, err
+	}
+
+	attached := map[string][]string{}
+	for _, ip := range publicIPs.PublicIPs {
+		if ip.InstanceID != "" {
+			attached[ip.InstanceID] = append(attached[ip.InstanceID], ip.Address)
+		}
+	}
+
+	for _, id := range input.InstanceID {
+		ips := attached[id]
+		if len(ips) == 0 {
+			continue
+		}
+
+		unassigned, err := client.UnassignPublicIP(&UnassignPublicIPInput{IPAddress: ips})
+		if err != nil {
+			return output, err
+		}
+
+		var messages []string
+		for _, ip := range unassigned.UnassignPublicIPs {
+			messages = append(messages, fmt.Sprintf("%s: %s", ip.Address, ip.Message))
+		}
+
+		output.Steps = append(output.Steps, TeardownStep{
+			Action:     "unassign-public-ip",
+			InstanceID: id,
+			IPAddress:  ips,
+			Message:    strings.Join(messages, "; "),
+		})
+
+		if !input.ReleasePublicIPs {
+			continue
+		}
+
+		released, err := client.ReleasePublicIP(&ReleasePublicIPInput{IPAddress: ips})
+		if err != nil {
+			return output, err
+		}
+
+		messages = nil
+		for _, ip := range released.ReleasePublicIPs {
+			messages = append(messages, fmt.Sprintf("%s: %s", ip.Address, ip.Message))
+		}
+
+		output.Steps = append(output.Steps, TeardownStep{
+			Action:     "release-public-ip",
+			InstanceID: id,
+			IPAddress:  ips,
+			Message:    strings.Join(messages, "; "),
+		})
+	}
+
+	terminated, err := client.TerminateInstance(&TerminateInstanceInput{
+		InstanceID: input.InstanceID,
+		Force:      input.Force,
+	})
+	if err != nil {
+		return output, err
+	}
+
+	for _, i := range terminated.TerminateInstances {
+		output.Steps = append(output.Steps, TeardownStep{
+			Action:     "terminate-instance",
+			InstanceID: i.ID,
+			Message:    i.Message,
+		})
+	}
+	output.TerminateInstances = terminated.TerminateInstances
+
+	return output, nil
+}
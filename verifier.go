@@ -0,0 +1,210 @@
+package atlantic
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSkew         = 5 * time.Minute
+	defaultMaxFutureSkew   = time.Minute
+	defaultReplayCacheSize = 50000
+	maxVerifiedBodySize    = 10 << 20
+)
+
+// Errors returned by Verifier.Verify.
+var (
+	ErrMissingCredentials = errors.New("atlantic: request is missing ACSAccessKeyId, Timestamp, Rndguid or Signature")
+	ErrUnknownAccessKey   = errors.New("atlantic: unknown access key")
+	ErrStaleTimestamp     = errors.New("atlantic: request timestamp is outside the allowed window")
+	ErrReplayedRequest    = errors.New("atlantic: request Rndguid has already been used")
+	ErrReplayCacheFull    = errors.New("atlantic: too many recent requests to check for replays")
+	ErrInvalidSignature   = errors.New("atlantic: invalid request signature")
+)
+
+// Verifier authenticates incoming requests signed with the same scheme the client uses, checking
+// ACSAccessKeyId, Timestamp, Rndguid and Signature. It is safe for concurrent use.
+type Verifier struct {
+	// Lookup returns the private key of an access key ID, or ErrUnknownAccessKey.
+	Lookup func(accessKeyID string) (Secret, error)
+	// MaxSkew is the largest allowed age of a request's timestamp. It defaults to five minutes.
+	MaxSkew time.Duration
+	// MaxFutureSkew is the furthest a request's timestamp may be ahead of now, to allow for clock
+	// drift. It defaults to one minute and is capped at MaxSkew.
+	MaxFutureSkew time.Duration
+	// ReplayCacheSize bounds the number of random GUIDs remembered for each access key. GUIDs are
+	// remembered until their timestamp is older than MaxSkew, which is at most MaxSkew plus
+	// MaxFutureSkew, and a key's requests are refused with ErrReplayCacheFull while its cache is
+	// full. It defaults to 50000, which allows a key over 130 requests per second with the
+	// default window.
+	ReplayCacheSize int
+
+	mu     sync.Mutex
+	caches map[string]*replayCache
+	now    func() time.Time
+}
+
+// replayCache holds the random GUIDs recently used by an access key, in the order they were seen.
+type replayCache struct {
+	seen  map[string]time.Time
+	order []string
+}
+
+// NewVerifier returns a verifier looking up private keys with lookup.
+func NewVerifier(lookup func(accessKeyID string) (Secret, error)) *Verifier {
+	return &Verifier{Lookup: lookup}
+}
+
+// Verify authenticates a request and returns its access key ID. Credentials are read from the
+// query string and from a form-encoded body, which is restored so the request can be forwarded.
+func (verifier *Verifier) Verify(r *http.Request) (string, error) {
+	form, err := requestForm(r)
+	if err != nil {
+		return "", err
+	}
+
+	accessKeyID := form.Get("ACSAccessKeyId")
+	timestamp := form.Get("Timestamp")
+	rndguid := form.Get("Rndguid")
+	signature := form.Get("Signature")
+
+	if accessKeyID == "" || timestamp == "" || rndguid == "" || signature == "" {
+		return "", ErrMissingCredentials
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrStaleTimestamp
+	}
+
+	maxSkew := verifier.MaxSkew
+	if maxSkew <= 0 {
+		maxSkew = defaultMaxSkew
+	}
+
+	now := time.Now()
+	if verifier.now != nil {
+		now = verifier.now()
+	}
+
+	maxFutureSkew := verifier.MaxFutureSkew
+	if maxFutureSkew <= 0 {
+		maxFutureSkew = defaultMaxFutureSkew
+	}
+	if maxFutureSkew > maxSkew {
+		maxFutureSkew = maxSkew
+	}
+
+	signedAt := time.Unix(seconds, 0)
+	if signedAt.Before(now.Add(-maxSkew)) || signedAt.After(now.Add(maxFutureSkew)) {
+		return "", ErrStaleTimestamp
+	}
+
+	privateKey, err := verifier.Lookup(accessKeyID)
+	if err != nil {
+		return "", err
+	}
+
+	expected := signHMAC(privateKey, seconds, rndguid)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", ErrInvalidSignature
+	}
+
+	if err := verifier.remember(accessKeyID, rndguid, signedAt.Add(maxSkew), now); err != nil {
+		return "", err
+	}
+
+	return accessKeyID, nil
+}
+
+// remember records a random GUID used by an access key until it expires. Each access key has its
+// own cache, so that one caller cannot fill the cache of another. Only expired GUIDs are dropped,
+// since a GUID dropped early could be replayed for the rest of its window.
+func (verifier *Verifier) remember(accessKeyID string, rndguid string, expires time.Time, now time.Time) error {
+	verifier.mu.Lock()
+	defer verifier.mu.Unlock()
+
+	if verifier.caches == nil {
+		verifier.caches = map[string]*replayCache{}
+	}
+
+	cache := verifier.caches[accessKeyID]
+	if cache == nil {
+		cache = &replayCache{seen: map[string]time.Time{}}
+		verifier.caches[accessKeyID] = cache
+	}
+
+	if expiry, ok := cache.seen[rndguid]; ok && now.Before(expiry) {
+		return ErrReplayedRequest
+	}
+
+	size := verifier.ReplayCacheSize
+	if size <= 0 {
+		size = defaultReplayCacheSize
+	}
+
+	// GUIDs are recorded roughly in expiry order, so expired ones are usually at the front, but
+	// a full cache is swept completely before giving up.
+	for len(cache.order) > 0 && !now.Before(cache.seen[cache.order[0]]) {
+		delete(cache.seen, cache.order[0])
+		cache.order = cache.order[1:]
+	}
+
+	if len(cache.order) >= size {
+		live := cache.order[:0]
+		for _, guid := range cache.order {
+			if now.Before(cache.seen[guid]) {
+				live = append(live, guid)
+			} else {
+				delete(cache.seen, guid)
+			}
+		}
+		cache.order = live
+	}
+
+	if len(cache.order) >= size {
+		return ErrReplayCacheFull
+	}
+
+	cache.seen[rndguid] = expires
+	cache.order = append(cache.order, rndguid)
+
+	return nil
+}
+
+// requestForm returns the query and form-encoded body parameters of a request, restoring the body.
+func requestForm(r *http.Request) (url.Values, error) {
+	form := r.URL.Query()
+
+	if r.Body == nil || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return form, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxVerifiedBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, vv := range values {
+		for _, v := range vv {
+			form.Add(k, v)
+		}
+	}
+
+	return form, nil
+}
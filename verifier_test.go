@@ -0,0 +1,177 @@
+package atlantic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestVerifier(now time.Time) *Verifier {
+	verifier := NewVerifier(func(accessKeyID string) (Secret, error) {
+		switch accessKeyID {
+		case "access":
+			return "private", nil
+		case "other":
+			return "other-private", nil
+		}
+		return "", ErrUnknownAccessKey
+	})
+	verifier.now = func() time.Time { return now }
+	return verifier
+}
+
+func newSignedRequest(accessKeyID string, privateKey Secret, timestamp int64, rndguid string) *http.Request {
+	form := url.Values{}
+	form.Add("ACSAccessKeyId", accessKeyID)
+	form.Add("Timestamp", strconv.FormatInt(timestamp, 10))
+	form.Add("Rndguid", rndguid)
+	form.Add("Signature", signHMAC(privateKey, timestamp, rndguid))
+	form.Add("Action", "list-instances")
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestVerifierAcceptsSignedRequest(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := newTestVerifier(now)
+
+	r := newSignedRequest("access", "private", now.Unix(), "guid")
+	accessKeyID, err := verifier.Verify(r)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if accessKeyID != "access" {
+		t.Errorf("Verify() = %q, want %q", accessKeyID, "access")
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "Action=list-instances") {
+		t.Errorf("body not restored, got %q", body)
+	}
+}
+
+func TestVerifierRejectsInvalidRequests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		want error
+	}{
+		{"missing credentials", httptest.NewRequest("POST", "/", nil), ErrMissingCredentials},
+		{"unknown access key", newSignedRequest("unknown", "private", now.Unix(), "guid"), ErrUnknownAccessKey},
+		{"wrong key", newSignedRequest("access", "wrong", now.Unix(), "guid"), ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTestVerifier(now).Verify(tt.r); err != tt.want {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifierSkew(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   error
+	}{
+		{"now", 0, nil},
+		{"oldest allowed", -5 * time.Minute, nil},
+		{"newest allowed", time.Minute, nil},
+		{"too old", -5*time.Minute - time.Second, ErrStaleTimestamp},
+		{"too new", time.Minute + time.Second, ErrStaleTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSignedRequest("access", "private", now.Add(tt.offset).Unix(), "guid")
+			if _, err := newTestVerifier(now).Verify(r); err != tt.want {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifierReplay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := newTestVerifier(now)
+
+	if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), "guid")); err != nil {
+		t.Fatalf("first Verify() error = %v", err)
+	}
+
+	if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), "guid")); err != ErrReplayedRequest {
+		t.Errorf("replayed Verify() error = %v, want %v", err, ErrReplayedRequest)
+	}
+
+	// Once the timestamp is stale, the replay is refused for its age instead.
+	verifier.now = func() time.Time { return now.Add(6 * time.Minute) }
+	if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), "guid")); err != ErrStaleTimestamp {
+		t.Errorf("late replayed Verify() error = %v, want %v", err, ErrStaleTimestamp)
+	}
+}
+
+func TestVerifierFullReplayCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := newTestVerifier(now)
+	verifier.ReplayCacheSize = 2
+
+	for _, guid := range []string{"victim", "filler"} {
+		if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), guid)); err != nil {
+			t.Fatalf("Verify(%s) error = %v", guid, err)
+		}
+	}
+
+	if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), "overflow")); err != ErrReplayCacheFull {
+		t.Errorf("Verify() on a full cache error = %v, want %v", err, ErrReplayCacheFull)
+	}
+
+	// Filling the cache must not push out live GUIDs.
+	if _, err := verifier.Verify(newSignedRequest("access", "private", now.Unix(), "victim")); err != ErrReplayedRequest {
+		t.Errorf("replayed Verify() error = %v, want %v", err, ErrReplayedRequest)
+	}
+
+	// Other access keys have caches of their own.
+	if _, err := verifier.Verify(newSignedRequest("other", "other-private", now.Unix(), "overflow")); err != nil {
+		t.Errorf("Verify() with another key error = %v", err)
+	}
+
+	// Expired GUIDs free their slots.
+	later := now.Add(5*time.Minute + time.Second)
+	verifier.now = func() time.Time { return later }
+	if _, err := verifier.Verify(newSignedRequest("access", "private", later.Unix(), "overflow")); err != nil {
+		t.Errorf("Verify() after expiry error = %v", err)
+	}
+}
+
+func TestVerifierMaxFutureSkew(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := newTestVerifier(now)
+	verifier.MaxFutureSkew = 10 * time.Minute
+
+	// MaxFutureSkew is capped at MaxSkew.
+	r := newSignedRequest("access", "private", now.Add(5*time.Minute+time.Second).Unix(), "guid")
+	if _, err := verifier.Verify(r); err != ErrStaleTimestamp {
+		t.Errorf("Verify() error = %v, want %v", err, ErrStaleTimestamp)
+	}
+
+	r = newSignedRequest("access", "private", now.Add(5*time.Minute).Unix(), "guid")
+	if _, err := verifier.Verify(r); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
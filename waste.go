@@ -0,0 +1,258 @@
+package atlantic
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Kinds of waste reported by FindWaste.
+const (
+	WasteUnassignedPublicIP = "unassigned-public-ip"
+	WasteUnusedSSHKey       = "unused-ssh-key"
+	WasteStoppedInstance    = "stopped-instance"
+	WasteIdleInstance       = "idle-instance"
+)
+
+// defaultIdleBytes is the combined transfer under which a running instance is considered idle.
+const defaultIdleBytes = 1 << 20
+
+// defaultIdleMinAge is the age under which a running instance is never considered idle.
+const defaultIdleMinAge = 7 * 24 * time.Hour
+
+// WasteOptions represents the options for finding orphaned and idle resources.
+type WasteOptions struct {
+	// ReferencedSSHKeys holds the IDs or names of the SSH keys used by launch templates or
+	// policies. Every other key is reported as unused. Keys are not checked when it is empty.
+	ReferencedSSHKeys []string
+	// IdleBytes is the combined inbound and outbound transfer under which a running instance is
+	// considered idle. It defaults to 1 MiB.
+	IdleBytes float64
+	// IdleMinAge is the age under which a running instance is never considered idle, since new
+	// instances have had no time to transfer data. It defaults to seven days. Instances whose
+	// creation date cannot be parsed are not considered idle.
+	IdleMinAge time.Duration
+	// ExcludePublicIPs holds addresses that are unassigned on purpose, such as an IPPool's spare
+	// IPs, and are never reported.
+	ExcludePublicIPs []string
+	// PublicIPRatePerHour prices reserved public IPs. It defaults to the rate in the client's budget.
+	PublicIPRatePerHour float64
+}
+
+// WasteFinding represents an orphaned or idle resource.
+type WasteFinding struct {
+	Kind         string
+	ResourceID   string
+	Name         string
+	Reason       string
+	MonthlyWaste float64
+}
+
+// WasteReport represents the orphaned and idle resources found on the account.
+type WasteReport struct {
+	Findings     []WasteFinding
+	MonthlyWaste float64
+}
+
+// FindWaste reports reserved public IPs assigned to no instance, SSH keys that are not referenced
+// when references are given, stopped instances that are still billed and running instances with
+// near-zero transfer, along with the estimated monthly cost of each.
+func (client *Client) FindWaste(options *WasteOptions) (*WasteReport, error) {
+	if options == nil {
+		options = &WasteOptions{}
+	}
+
+	idleBytes := options.IdleBytes
+	if idleBytes <= 0 {
+		idleBytes = defaultIdleBytes
+	}
+
+	idleMinAge := options.IdleMinAge
+	if idleMinAge <= 0 {
+		idleMinAge = defaultIdleMinAge
+	}
+
+	excluded := map[string]bool{}
+	for _, address := range options.ExcludePublicIPs {
+		excluded[address] = true
+	}
+
+	ipRate := options.PublicIPRatePerHour
+	if ipRate <= 0 && client.Budget != nil {
+		ipRate = client.Budget.PublicIPRatePerHour
+	}
+
+	report := &WasteReport{Findings: []WasteFinding{}}
+
+	publicIPs, err := client.ListPublicIPs(&ListPublicIPsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range publicIPs.PublicIPs {
+		if ip.InstanceID == "" && !excluded[ip.Address] {
+			report.add(WasteFinding{
+				Kind:         WasteUnassignedPublicIP,
+				ResourceID:   ip.Address,
+				Name:         ip.Location,
+				Reason:       "reserved but not assigned to an instance",
+				MonthlyWaste: ipRate * HoursPerMonth,
+			})
+		}
+	}
+
+	if len(options.ReferencedSSHKeys) > 0 {
+		keys, err := client.ListSSHKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		referenced := map[string]bool{}
+		for _, k := range options.ReferencedSSHKeys {
+			referenced[k] = true
+		}
+
+		for _, k := range keys.Keys {
+			if !referenced[k.ID] && !referenced[k.Name] {
+				report.add(WasteFinding{
+					Kind:       WasteUnusedSSHKey,
+					ResourceID: k.ID,
+					Name:       k.Name,
+					Reason:     "not referenced by any launch template or policy",
+				})
+			}
+		}
+	}
+
+	instances, err := client.describeInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range instances {
+		rate, _ := parseNumber(i.RatePerHour)
+
+		if i.VMStatus == InstanceStatusStopped {
+			report.add(WasteFinding{
+				Kind:         WasteStoppedInstance,
+				ResourceID:   i.ID,
+				Name:         i.VMName,
+				Reason:       fmt.Sprintf("stopped but billed at %s/hr", i.RatePerHour),
+				MonthlyWaste: rate * HoursPerMonth,
+			})
+			continue
+		}
+
+		created, err := parseTime(i.VMCreatedDate)
+		if err != nil || time.Since(created) < idleMinAge {
+			continue
+		}
+
+		bytesIn, errIn := parseNumber(i.BytesIn)
+		bytesOut, errOut := parseNumber(i.BytesOut)
+		if errIn == nil && errOut == nil && bytesIn+bytesOut < idleBytes {
+			report.add(WasteFinding{
+				Kind:         WasteIdleInstance,
+				ResourceID:   i.ID,
+				Name:         i.VMName,
+				Reason:       fmt.Sprintf("transferred %.0f bytes in and %.0f bytes out", bytesIn, bytesOut),
+				MonthlyWaste: rate * HoursPerMonth,
+			})
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(a, b int) bool {
+		return report.Findings[a].MonthlyWaste > report.Findings[b].MonthlyWaste
+	})
+
+	return report, nil
+}
+
+func (report *WasteReport) add(finding WasteFinding) {
+	report.Findings = append(report.Findings, finding)
+	report.MonthlyWaste += finding.MonthlyWaste
+}
+
+// CleanupOptions represents the options for cleaning up waste findings.
+type CleanupOptions struct {
+	// DryRun reports the action each finding would get without taking it.
+	DryRun bool
+	// Confirm is asked before each finding is cleaned up, and findings it rejects are skipped.
+	// It must be set unless DryRun is.
+	Confirm func(WasteFinding) bool
+}
+
+// CleanupResult represents the outcome of cleaning up a single finding.
+type CleanupResult struct {
+	Finding WasteFinding
+	Action  string
+	Done    bool
+	Skipped bool
+	Err     string
+}
+
+// Cleanup releases unassigned public IPs, deletes unused SSH keys and terminates stopped and idle
+// instances from a waste report. Terminations go through the client's termination policy.
+// Failures are recorded per finding and do not stop the remaining findings.
+func (client *Client) Cleanup(report *WasteReport, options *CleanupOptions) ([]CleanupResult, error) {
+	if options == nil {
+		options = &CleanupOptions{}
+	}
+
+	if !options.DryRun && options.Confirm == nil {
+		return nil, fmt.Errorf("atlantic: Confirm must be provided unless DryRun is set")
+	}
+
+	results := []CleanupResult{}
+	for _, f := range report.Findings {
+		result := CleanupResult{Finding: f}
+
+		switch f.Kind {
+		case WasteUnassignedPublicIP:
+			result.Action = "release-public-ip"
+		case WasteUnusedSSHKey:
+			result.Action = "delete-sshkey"
+		case WasteStoppedInstance, WasteIdleInstance:
+			result.Action = "terminate-instance"
+		default:
+			result.Skipped = true
+			result.Err = fmt.Sprintf("unknown finding kind %q", f.Kind)
+			results = append(results, result)
+			continue
+		}
+
+		if options.DryRun || !options.Confirm(f) {
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		var err error
+		switch result.Action {
+		case "release-public-ip":
+			_, err = client.ReleasePublicIP(&ReleasePublicIPInput{IPAddress: []string{f.ResourceID}})
+		case "delete-sshkey":
+			_, err = client.DeleteSSHKey(&DeleteSSHKeyInput{KeyIDs: []string{f.ResourceID}})
+		case "terminate-instance":
+			_, err = client.TerminateInstance(&TerminateInstanceInput{InstanceID: []string{f.ResourceID}})
+		}
+
+		if err != nil {
+			result.Err = err.Error()
+		} else {
+			result.Done = true
+		}
+
+		results = append(results, result)
+
+		message := f.Reason
+		if result.Err != "" {
+			message += ": " + result.Err
+		}
+		if err := client.audit(AuditRecord{Action: result.Action, Target: f.ResourceID, Message: message}); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}